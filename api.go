@@ -24,6 +24,7 @@ package amocrm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +33,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -79,11 +81,25 @@ type api struct {
 	redirectURL  string
 
 	domain string
-	token  Token
+
+	// mu guards token, refresh, limiter and retry.
+	mu      sync.Mutex
+	token   Token
+	refresh *refreshCall
+	limiter RateLimiter
+	retry   RetryPolicy
 
 	http *http.Client
 
 	storage TokenStorage
+
+	customers customersGate
+}
+
+// refreshCall is an in-flight token refresh shared by concurrent requests.
+type refreshCall struct {
+	done chan struct{}
+	err  error
 }
 
 func newAPI(clientID, clientSecret, redirectURL string, storage TokenStorage) *api {
@@ -94,37 +110,41 @@ func newAPI(clientID, clientSecret, redirectURL string, storage TokenStorage) *a
 		http: &http.Client{
 			Timeout: requestTimeout,
 		},
+		limiter: NewRateLimiter(DefaultRateLimit, DefaultRateLimit),
+		retry:   DefaultRetryPolicy,
 
 		storage: storage,
 	}
 }
 
-func (a *api) do(ep endpoint, method string, q url.Values, h http.Header, data interface{}) (*http.Response, error) {
-	if a.token == nil {
+func (a *api) do(ctx context.Context, ep endpoint, method string, q url.Values, h http.Header, data interface{}) (*http.Response, error) {
+	token := a.currentToken()
+	if token == nil {
 		return nil, errors.New("invalid token")
 	}
 
-	if a.token.Expired() {
-		if err := a.refreshToken(); err != nil {
+	if token.Expired() {
+		if err := a.refreshToken(ctx, token); err != nil {
 			return nil, err
 		}
+		token = a.currentToken()
 	}
 
-	header := a.header()
+	header := a.header(token)
 	for k, v := range h {
 		if _, reserved := header[k]; !reserved {
 			header[k] = v
 		}
 	}
 
-	var body io.Reader
+	var body []byte
 	if data != nil {
 		header.Set("Content-Type", "application/json")
 		jdata, err := json.Marshal(data)
 		if err != nil {
 			return nil, err
 		}
-		body = bytes.NewReader(jdata)
+		body = jdata
 	}
 
 	apiURL, err := a.url(ep.path(), q)
@@ -132,15 +152,83 @@ func (a *api) do(ep endpoint, method string, q url.Values, h http.Header, data i
 		return nil, err
 	}
 
-	r, err := http.NewRequest(method, apiURL.String(), body)
+	// POST requests create entities, so they are only safe to repeat when
+	// the caller can match the result by request_id.
+	retryable := method != http.MethodPost || hasRequestID(body)
+	policy := a.retryPolicy()
+
+	replayed := false
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := a.send(ctx, method, apiURL, header, body)
+
+		// amoCRM may revoke the access token before it expires by the
+		// local clock: refresh it and replay the request once.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !replayed {
+			discardBody(resp)
+			if err := a.refreshToken(ctx, token); err != nil {
+				return nil, err
+			}
+			token = a.currentToken()
+			header.Set("Authorization", authorization(token))
+
+			replayed = true
+			attempt--
+			continue
+		}
+
+		if !retryable || attempt >= policy.MaxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := policy.delay(attempt, resp)
+		if policy.MaxElapsed > 0 && time.Since(start)+delay > policy.MaxElapsed {
+			return resp, err
+		}
+
+		info := RetryInfo{Attempt: attempt, Method: method, URL: apiURL.String(), Err: err, Delay: delay}
+		if resp != nil {
+			info.StatusCode = resp.StatusCode
+			discardBody(resp)
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(info)
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (a *api) send(ctx context.Context, method string, u *url.URL, header http.Header, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
 	if err != nil {
 		return nil, err
 	}
-	r.Header = header
+	r.Header = header.Clone()
+
+	if limiter := a.rateLimiter(); limiter != nil {
+		if err := limiter.Wait(ctx, a.domain); err != nil {
+			return nil, err
+		}
+	}
 
 	return a.http.Do(r)
 }
 
+// discardBody drains and closes the body of a response that is not
+// returned to the caller, so the connection can be reused.
+func discardBody(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<20))
+	_ = resp.Body.Close()
+}
+
 func (a *api) read(response *http.Response, target interface{}) (err error) {
 	defer func() {
 		if clErr := response.Body.Close(); clErr != nil {
@@ -152,10 +240,17 @@ func (a *api) read(response *http.Response, target interface{}) (err error) {
 		}
 	}()
 
+	// amoCRM answers list requests that match nothing with an empty body.
+	if response.StatusCode == http.StatusNoContent {
+		return
+	}
+
 	if response.StatusCode >= 400 {
 		var data []byte
-		data, err = ioutil.ReadAll(response.Body)
-		err = fmt.Errorf("invalid status: %v", string(data))
+		if data, err = ioutil.ReadAll(io.LimitReader(response.Body, 1<<20)); err != nil {
+			return
+		}
+		err = newAPIError(response.StatusCode, data)
 		return
 	}
 
@@ -163,11 +258,43 @@ func (a *api) read(response *http.Response, target interface{}) (err error) {
 	return
 }
 
+// get fetches a single entity into target. amoCRM answers requests for
+// missing entities with 204 No Content, which is reported as ErrNotFound.
+func (a *api) get(ctx context.Context, ep endpoint, q url.Values, target interface{}) error {
+	resp, err := a.do(ctx, ep, http.MethodGet, q, nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		discardBody(resp)
+		return ErrNotFound
+	}
+
+	return a.read(resp, target)
+}
+
+// delete removes the entity at ep.
+func (a *api) delete(ctx context.Context, ep endpoint) error {
+	resp, err := a.do(ctx, ep, http.MethodDelete, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return a.read(resp, nil)
+	}
+	discardBody(resp)
+
+	return nil
+}
+
 func (a *api) setToken(token Token) error {
 	if token == nil {
 		return errors.New("invalid token")
 	}
+
+	a.mu.Lock()
 	a.token = token
+	a.mu.Unlock()
 
 	if a.storage != nil {
 		return a.storage.SetToken(token)
@@ -176,6 +303,13 @@ func (a *api) setToken(token Token) error {
 	return nil
 }
 
+func (a *api) currentToken() Token {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.token
+}
+
 func (a *api) loadToken() (Token, error) {
 	if a.storage == nil {
 		return nil, nil
@@ -184,6 +318,34 @@ func (a *api) loadToken() (Token, error) {
 	return a.storage.GetToken()
 }
 
+func (a *api) rateLimiter() RateLimiter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.limiter
+}
+
+func (a *api) setRateLimiter(limiter RateLimiter) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.limiter = limiter
+}
+
+func (a *api) retryPolicy() RetryPolicy {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.retry
+}
+
+func (a *api) setRetryPolicy(policy RetryPolicy) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.retry = policy
+}
+
 func (a *api) setDomain(domain string) error {
 	if !isValidDomain(domain) {
 		return errors.New("invalid domain")
@@ -212,7 +374,7 @@ func (a *api) authorizationURL(state, mode string) (*url.URL, error) {
 	return url.Parse(authURL)
 }
 
-func (a *api) getToken(grant GrantType, options url.Values, header http.Header) (Token, error) {
+func (a *api) getToken(ctx context.Context, grant GrantType, options url.Values, header http.Header) (Token, error) {
 	if !isValidDomain(a.domain) {
 		return nil, oauth2Err("invalid accounts domain")
 	}
@@ -254,20 +416,16 @@ func (a *api) getToken(grant GrantType, options url.Values, header http.Header)
 		}
 	}
 
-	// Create request body
-	reqBody := ioutil.NopCloser(strings.NewReader(data.Encode()))
-
 	// Build request
-	req := &http.Request{
-		Method: http.MethodPost,
-		Header: reqHeader,
-		URL:    tokenURL,
-		Body:   reqBody,
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL.String(), strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, oauth2Err("build request")
 	}
+	req.Header = reqHeader
 
 	resp, err := a.http.Do(req)
 	if err != nil {
-		return nil, oauth2Err("send request")
+		return nil, oauth2Err("send request: %w", err)
 	}
 
 	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
@@ -279,7 +437,15 @@ func (a *api) getToken(grant GrantType, options url.Values, header http.Header)
 	}
 
 	if statusCode := resp.StatusCode; statusCode < 200 || statusCode > 299 {
-		return nil, oauth2Err("fetch token: response: %v - %s, request: %+v", resp.Status, respBody, req)
+		apiErr := newAPIError(statusCode, respBody)
+
+		// Rejected refresh grant means the refresh token is revoked or
+		// already used. A rejected authorization code is the caller's error.
+		if grant.code == refreshTokenGrant.code && (statusCode == http.StatusBadRequest || statusCode == http.StatusUnauthorized) {
+			return nil, oauth2Err("fetch token: %w: %v", ErrReauthorizationRequired, apiErr)
+		}
+
+		return nil, oauth2Err("fetch token: %w", apiErr)
 	}
 
 	var jsonToken tokenJSON
@@ -301,25 +467,64 @@ func (a *api) getToken(grant GrantType, options url.Values, header http.Header)
 	return token, nil
 }
 
-func (a *api) refreshToken() error {
-	if a.token.RefreshToken() == "" {
-		return oauth2Err("empty refresh token")
+// refreshToken replaces stale token with a new one. amoCRM refresh tokens
+// are single-use, so concurrent callers share one exchange, and nothing
+// is exchanged if stale has already been replaced.
+func (a *api) refreshToken(ctx context.Context, stale Token) error {
+	for {
+		a.mu.Lock()
+		if a.token == nil || a.token.AccessToken() != stale.AccessToken() {
+			a.mu.Unlock()
+			return nil
+		}
+
+		call := a.refresh
+		if call == nil {
+			call = &refreshCall{done: make(chan struct{})}
+			a.refresh = call
+			a.mu.Unlock()
+
+			call.err = a.exchangeRefreshToken(ctx, stale)
+
+			a.mu.Lock()
+			a.refresh = nil
+			a.mu.Unlock()
+			close(call.done)
+
+			return call.err
+		}
+		a.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-call.done:
+		}
+
+		// The caller that started the exchange has given up, try again
+		// with our own context.
+		if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+			continue
+		}
+
+		return call.err
 	}
+}
 
-	token, err := a.getToken(refreshTokenGrant, url.Values{
+func (a *api) exchangeRefreshToken(ctx context.Context, stale Token) error {
+	if stale.RefreshToken() == "" {
+		return oauth2Err("%w: empty refresh token", ErrReauthorizationRequired)
+	}
+
+	token, err := a.getToken(ctx, refreshTokenGrant, url.Values{
 		"grant_type":    []string{"refresh_token"},
-		"refresh_token": []string{a.token.RefreshToken()},
+		"refresh_token": []string{stale.RefreshToken()},
 	}, nil)
 	if err != nil {
 		return err
 	}
 
-	a.token = token
-	if a.storage != nil {
-		return a.storage.SetToken(token)
-	}
-
-	return nil
+	return a.setToken(token)
 }
 
 func (a *api) url(path string, q url.Values) (*url.URL, error) {
@@ -332,15 +537,17 @@ func (a *api) url(path string, q url.Values) (*url.URL, error) {
 	return url.Parse(endpointURL)
 }
 
-func (a *api) header() http.Header {
-	authHeader := a.token.TokenType() + " " + a.token.AccessToken()
-
+func (a *api) header(token Token) http.Header {
 	header := a.baseHeader()
-	header["Authorization"] = []string{authHeader}
+	header["Authorization"] = []string{authorization(token)}
 
 	return header
 }
 
+func authorization(token Token) string {
+	return token.TokenType() + " " + token.AccessToken()
+}
+
 func (a *api) baseHeader() http.Header {
 	return http.Header{
 		"User-Agent": []string{userAgent},
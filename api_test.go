@@ -0,0 +1,91 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDomain = "example.amocrm.ru"
+
+// newTestAPI returns an api whose requests to any host are served by h.
+// Retries and rate limiting are disabled unless a test sets its own.
+func newTestAPI(t *testing.T, h http.Handler) *api {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(h)
+	t.Cleanup(srv.Close)
+
+	transport := srv.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+
+	a := newAPI(clientID, clientSecret, redirectURL, nil)
+	a.http = &http.Client{Transport: transport, Timeout: requestTimeout}
+	a.setRetryPolicy(RetryPolicy{})
+	a.setRateLimiter(nil)
+	require.NoError(t, a.setDomain(testDomain))
+	require.NoError(t, a.setToken(NewToken("access_token", "refresh_token", "bearer", time.Time{})))
+
+	return a
+}
+
+const (
+	clientID     = "client_id"
+	clientSecret = "client_secret"
+	redirectURL  = "redirect_url"
+)
+
+func TestAPI_Do_ContextCanceled(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := a.do(ctx, leadsEndpoint, http.MethodGet, nil, nil, nil)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+}
+
+func TestAPI_Do_RefreshUsesContext(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL)
+	}))
+	a.token = NewToken("access_token", "refresh_token", "bearer", time.Now().Add(-time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := a.do(ctx, leadsEndpoint, http.MethodGet, nil, nil, nil)
+	require.True(t, errors.Is(err, context.Canceled), err)
+}
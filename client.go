@@ -21,6 +21,7 @@
 package amocrm
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"net/url"
@@ -30,14 +31,32 @@ import (
 type Client interface {
 	AuthorizeURL(state, mode string) (*url.URL, error)
 	TokenByCode(code string) (Token, error)
+	TokenByCodeContext(ctx context.Context, code string) (Token, error)
 	LoadTokenOrAuthorize(code string) error
+	LoadTokenOrAuthorizeContext(ctx context.Context, code string) error
 	SetToken(token Token) error
 	SetDomain(domain string) error
+	SetRateLimiter(limiter RateLimiter)
+	SetRetryPolicy(policy RetryPolicy)
 	Accounts() Accounts
 	Leads() Leads
 	Contacts() Contacts
+	Companies() Companies
+	CustomFields(entity EntityType) CustomFields
+	Pipelines() Pipelines
+	Tasks() Tasks
+	Notes(entity EntityType) Notes
+	Tags(entity EntityType) Tags
+	Users() Users
+	Links(entity EntityType) Links
+	Catalogs() Catalogs
+	CatalogElements(catalogID int) CatalogElements
+	Customers() Customers
+	Unsorted() UnsortedLeads
+	Webhooks() Webhooks
 	Calls() Calls
 	EventsV2() EventsV2
+	Events() Events
 }
 
 // Verify interface compliance.
@@ -103,7 +122,26 @@ func (a *amoCRM) SetDomain(domain string) error {
 	return a.api.setDomain(domain)
 }
 
+// SetRateLimiter replaces the limiter shared by all repositories of the
+// client. By default requests are limited to DefaultRateLimit per second
+// for every domain; nil disables rate limiting.
+func (a *amoCRM) SetRateLimiter(limiter RateLimiter) {
+	a.api.setRateLimiter(limiter)
+}
+
+// SetRetryPolicy replaces DefaultRetryPolicy used to repeat requests
+// failed with 429, 5xx or network errors. Zero RetryPolicy disables retries.
+func (a *amoCRM) SetRetryPolicy(policy RetryPolicy) {
+	a.api.setRetryPolicy(policy)
+}
+
 func (a *amoCRM) LoadTokenOrAuthorize(authCode string) error {
+	return a.LoadTokenOrAuthorizeContext(context.Background(), authCode)
+}
+
+// LoadTokenOrAuthorizeContext is like LoadTokenOrAuthorize but uses
+// ctx for the authorization code exchange.
+func (a *amoCRM) LoadTokenOrAuthorizeContext(ctx context.Context, authCode string) error {
 	token, err := a.api.loadToken()
 	if err != nil {
 		return err
@@ -113,7 +151,7 @@ func (a *amoCRM) LoadTokenOrAuthorize(authCode string) error {
 		return a.api.setToken(token)
 	}
 
-	token, err = a.TokenByCode(authCode)
+	token, err = a.TokenByCodeContext(ctx, authCode)
 	if err != nil {
 		return err
 	}
@@ -124,7 +162,12 @@ func (a *amoCRM) LoadTokenOrAuthorize(authCode string) error {
 // TokenByCode makes a handshake with amoCRM, exchanging given
 // authorization code for a set of tokens.
 func (a *amoCRM) TokenByCode(code string) (Token, error) {
-	return a.api.getToken(authorizationCodeGrant, url.Values{
+	return a.TokenByCodeContext(context.Background(), code)
+}
+
+// TokenByCodeContext is like TokenByCode but uses ctx for the handshake.
+func (a *amoCRM) TokenByCodeContext(ctx context.Context, code string) (Token, error) {
+	return a.api.getToken(ctx, authorizationCodeGrant, url.Values{
 		"code":       []string{code},
 		"grant_type": []string{"authorization_code"},
 	}, nil)
@@ -143,6 +186,58 @@ func (a *amoCRM) Contacts() Contacts {
 	return newContacts(a.api)
 }
 
+func (a *amoCRM) Companies() Companies {
+	return newCompanies(a.api)
+}
+
+func (a *amoCRM) CustomFields(entity EntityType) CustomFields {
+	return newCustomFields(a.api, entity)
+}
+
+func (a *amoCRM) Pipelines() Pipelines {
+	return newPipelines(a.api)
+}
+
+func (a *amoCRM) Tasks() Tasks {
+	return newTasks(a.api)
+}
+
+func (a *amoCRM) Notes(entity EntityType) Notes {
+	return newNotes(a.api, entity)
+}
+
+func (a *amoCRM) Tags(entity EntityType) Tags {
+	return newTags(a.api, entity)
+}
+
+func (a *amoCRM) Users() Users {
+	return newUsers(a.api)
+}
+
+func (a *amoCRM) Links(entity EntityType) Links {
+	return newLinks(a.api, entity)
+}
+
+func (a *amoCRM) Catalogs() Catalogs {
+	return newCatalogs(a.api)
+}
+
+func (a *amoCRM) CatalogElements(catalogID int) CatalogElements {
+	return newCatalogElements(a.api, catalogID)
+}
+
+func (a *amoCRM) Customers() Customers {
+	return newCustomers(a.api)
+}
+
+func (a *amoCRM) Unsorted() UnsortedLeads {
+	return newUnsorted(a.api)
+}
+
+func (a *amoCRM) Webhooks() Webhooks {
+	return newWebhooks(a.api)
+}
+
 func (a *amoCRM) Calls() Calls {
 	return newCalls(a.api)
 }
@@ -150,3 +245,7 @@ func (a *amoCRM) Calls() Calls {
 func (a *amoCRM) EventsV2() EventsV2 {
 	return newEventsV2(a.api)
 }
+
+func (a *amoCRM) Events() Events {
+	return newEvents(a.api)
+}
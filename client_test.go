@@ -30,7 +30,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/sintanial/amocrm"
+	"github.com/ros-tel/amocrm"
 )
 
 var (
@@ -0,0 +1,414 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// FieldType is a type of custom field.
+type FieldType string
+
+// Custom field types supported by amoCRM.
+const (
+	FieldTypeText          FieldType = "text"
+	FieldTypeNumeric       FieldType = "numeric"
+	FieldTypeCheckbox      FieldType = "checkbox"
+	FieldTypeSelect        FieldType = "select"
+	FieldTypeMultiselect   FieldType = "multiselect"
+	FieldTypeDate          FieldType = "date"
+	FieldTypeDateTime      FieldType = "date_time"
+	FieldTypeBirthday      FieldType = "birthday"
+	FieldTypeURL           FieldType = "url"
+	FieldTypeTextarea      FieldType = "textarea"
+	FieldTypeRadiobutton   FieldType = "radiobutton"
+	FieldTypeStreetAddress FieldType = "streetaddress"
+	FieldTypeSmartAddress  FieldType = "smart_address"
+	FieldTypeLegalEntity   FieldType = "legal_entity"
+	FieldTypePrice         FieldType = "price"
+	FieldTypeCategory      FieldType = "category"
+	FieldTypeItems         FieldType = "items"
+	FieldTypeTrackingData  FieldType = "tracking_data"
+	FieldTypeLinkedEntity  FieldType = "linked_entity"
+	FieldTypeChainedList   FieldType = "chained_list"
+	FieldTypeMonetary      FieldType = "monetary"
+	FieldTypeFile          FieldType = "file"
+	FieldTypePayer         FieldType = "payer"
+	FieldTypeSupplier      FieldType = "supplier"
+	FieldTypeMultitext     FieldType = "multitext"
+)
+
+// Enum codes of multitext fields, e.g. PHONE and EMAIL.
+const (
+	EnumWork   = "WORK"
+	EnumWorkDD = "WORKDD"
+	EnumMob    = "MOB"
+	EnumFax    = "FAX"
+	EnumHome   = "HOME"
+	EnumPriv   = "PRIV"
+	EnumOther  = "OTHER"
+)
+
+// CustomFieldValue holds values of a single custom field of an entity.
+// Set either FieldID or FieldCode when writing.
+type CustomFieldValue struct {
+	FieldID   int               `json:"field_id,omitempty"`
+	FieldCode string            `json:"field_code,omitempty"`
+	FieldName string            `json:"field_name,omitempty"`
+	FieldType FieldType         `json:"field_type,omitempty"`
+	Values    []CustomFieldItem `json:"values,omitempty"`
+	Clear     bool              `json:"-"` // erase the field, Values are not sent
+}
+
+// MarshalJSON sends null values when Clear is set, which is how amoCRM
+// erases a field.
+func (v CustomFieldValue) MarshalJSON() ([]byte, error) {
+	type plain CustomFieldValue
+	if !v.Clear {
+		return json.Marshal(plain(v))
+	}
+
+	return json.Marshal(struct {
+		plain
+		Values []CustomFieldItem `json:"values"`
+	}{plain: plain(v)})
+}
+
+// CustomFieldItem is a single value of a custom field. Its Value shape
+// depends on the field type, use constructors such as TextValue or
+// EnumValue to build it and getters such as AsString to read it.
+type CustomFieldItem struct {
+	Value    json.RawMessage `json:"value,omitempty"`
+	EnumID   int             `json:"enum_id,omitempty"`
+	EnumCode string          `json:"enum_code,omitempty"`
+	Subtype  string          `json:"subtype,omitempty"`
+}
+
+// LegalEntity is a value of legal_entity fields.
+type LegalEntity struct {
+	Name                      string `json:"name"`
+	EntityType                int    `json:"entity_type,omitempty"`
+	VatID                     string `json:"vat_id,omitempty"`
+	TaxRegistrationReasonCode string `json:"tax_registration_reason_code,omitempty"`
+	Address                   string `json:"address,omitempty"`
+	Kpp                       string `json:"kpp,omitempty"`
+	ExternalUID               string `json:"external_uid,omitempty"`
+}
+
+// ItemsValue is a value of items fields.
+type ItemsValue struct {
+	SKU         string        `json:"sku,omitempty"`
+	Description string        `json:"description,omitempty"`
+	UnitPrice   float64       `json:"unit_price"`
+	Quantity    float64       `json:"quantity"`
+	UnitType    string        `json:"unit_type,omitempty"`
+	Discount    *ItemDiscount `json:"discount,omitempty"`
+	VatRateID   int           `json:"vat_rate_id,omitempty"`
+	ExternalUID string        `json:"external_uid,omitempty"`
+}
+
+// ItemDiscount is a discount of ItemsValue. Type is "amount" or "percentage".
+type ItemDiscount struct {
+	Type  string  `json:"type"`
+	Value float64 `json:"value"`
+}
+
+// LinkedEntityValue is a value of linked_entity fields.
+type LinkedEntityValue struct {
+	Name       string `json:"name,omitempty"`
+	EntityID   int    `json:"entity_id"`
+	EntityType string `json:"entity_type"`
+	CatalogID  int    `json:"catalog_id,omitempty"`
+}
+
+// ChainedListValue is a value of chained_list fields.
+type ChainedListValue struct {
+	CatalogID        int `json:"catalog_id"`
+	CatalogElementID int `json:"catalog_element_id"`
+}
+
+// FileValue is a value of file fields.
+type FileValue struct {
+	FileUUID    string `json:"file_uuid"`
+	VersionUUID string `json:"version_uuid"`
+	FileName    string `json:"file_name,omitempty"`
+	FileSize    int    `json:"file_size,omitempty"`
+	IsDeleted   bool   `json:"is_deleted,omitempty"`
+}
+
+// PayerValue is a value of payer and supplier fields.
+type PayerValue struct {
+	Name       string `json:"name,omitempty"`
+	EntityID   int    `json:"entity_id,omitempty"`
+	EntityType string `json:"entity_type,omitempty"`
+}
+
+// TextValue builds a value of text, textarea, url, streetaddress and
+// tracking_data fields.
+func TextValue(s string) CustomFieldItem {
+	return CustomFieldItem{Value: marshal(s)}
+}
+
+// NumericValue builds a value of numeric, price and monetary fields.
+// NaN and infinities are rejected, amoCRM has no way to store them.
+func NumericValue(f float64) (CustomFieldItem, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return CustomFieldItem{}, fmt.Errorf("numeric custom field value is not finite: %v", f)
+	}
+
+	return CustomFieldItem{Value: marshal(f)}, nil
+}
+
+// BoolValue builds a value of checkbox fields.
+func BoolValue(b bool) CustomFieldItem {
+	return CustomFieldItem{Value: marshal(b)}
+}
+
+// TimeValue builds a value of date, date_time and birthday fields.
+func TimeValue(t time.Time) CustomFieldItem {
+	return CustomFieldItem{Value: marshal(t.Unix())}
+}
+
+// EnumValue builds a value of select, multiselect, radiobutton and
+// category fields.
+func EnumValue(enumID int) CustomFieldItem {
+	return CustomFieldItem{EnumID: enumID}
+}
+
+// MultitextValue builds a value of multitext fields such as PHONE or EMAIL,
+// enumCode is one of EnumWork, EnumMob, etc.
+func MultitextValue(enumCode, value string) CustomFieldItem {
+	return CustomFieldItem{Value: marshal(value), EnumCode: enumCode}
+}
+
+// SmartAddressValue builds a value of smart_address fields.
+func SmartAddressValue(subtype, value string) CustomFieldItem {
+	return CustomFieldItem{Value: marshal(value), Subtype: subtype}
+}
+
+// ObjectValue builds a value of legal_entity, items, linked_entity,
+// chained_list, file, payer and supplier fields from LegalEntity,
+// ItemsValue, LinkedEntityValue, ChainedListValue, FileValue or PayerValue.
+func ObjectValue(v interface{}) (CustomFieldItem, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return CustomFieldItem{}, err
+	}
+
+	return CustomFieldItem{Value: data}, nil
+}
+
+// marshal encodes strings, booleans, integers and finite floats, which
+// json.Marshal never fails on.
+func marshal(v interface{}) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+var errEmptyValue = errors.New("empty custom field value")
+
+// AsString returns the value as a string. Numbers and booleans are
+// returned in their JSON form.
+func (i CustomFieldItem) AsString() string {
+	var s string
+	if err := json.Unmarshal(i.Value, &s); err == nil {
+		return s
+	}
+	if bytes.Equal(i.Value, []byte("null")) {
+		return ""
+	}
+
+	return string(i.Value)
+}
+
+// AsFloat returns the value of numeric fields, which amoCRM returns both
+// as numbers and as strings.
+func (i CustomFieldItem) AsFloat() (float64, error) {
+	if len(i.Value) == 0 {
+		return 0, errEmptyValue
+	}
+
+	var f float64
+	if err := json.Unmarshal(i.Value, &f); err == nil {
+		return f, nil
+	}
+
+	return strconv.ParseFloat(i.AsString(), 64)
+}
+
+// AsBool returns the value of checkbox fields.
+func (i CustomFieldItem) AsBool() (bool, error) {
+	if len(i.Value) == 0 {
+		return false, errEmptyValue
+	}
+
+	var b bool
+	if err := json.Unmarshal(i.Value, &b); err == nil {
+		return b, nil
+	}
+
+	return strconv.ParseBool(i.AsString())
+}
+
+// AsTime returns the value of date, date_time and birthday fields given
+// either as Unix timestamp or as RFC 3339 string.
+func (i CustomFieldItem) AsTime() (time.Time, error) {
+	if len(i.Value) == 0 {
+		return time.Time{}, errEmptyValue
+	}
+
+	if ts, err := i.AsFloat(); err == nil {
+		return time.Unix(int64(ts), 0), nil
+	}
+
+	return time.Parse(time.RFC3339, i.AsString())
+}
+
+// Decode unmarshals object values into v, e.g. *LegalEntity or *ItemsValue.
+func (i CustomFieldItem) Decode(v interface{}) error {
+	if len(i.Value) == 0 {
+		return errEmptyValue
+	}
+
+	return json.Unmarshal(i.Value, v)
+}
+
+func (v CustomFieldValue) first() CustomFieldItem {
+	if len(v.Values) == 0 {
+		return CustomFieldItem{}
+	}
+
+	return v.Values[0]
+}
+
+// AsString returns the first value as a string.
+func (v CustomFieldValue) AsString() string {
+	return v.first().AsString()
+}
+
+// AsFloat returns the first value as a number.
+func (v CustomFieldValue) AsFloat() (float64, error) {
+	return v.first().AsFloat()
+}
+
+// AsBool returns the first value as a boolean.
+func (v CustomFieldValue) AsBool() (bool, error) {
+	return v.first().AsBool()
+}
+
+// AsTime returns the first value as time.
+func (v CustomFieldValue) AsTime() (time.Time, error) {
+	return v.first().AsTime()
+}
+
+// Decode unmarshals the first value into v.
+func (v CustomFieldValue) Decode(target interface{}) error {
+	return v.first().Decode(target)
+}
+
+// Strings returns all values as strings, e.g. all phones of a contact.
+func (v CustomFieldValue) Strings() []string {
+	values := make([]string, 0, len(v.Values))
+	for _, item := range v.Values {
+		values = append(values, item.AsString())
+	}
+
+	return values
+}
+
+// EnumIDs returns enum IDs of selected options of select, multiselect,
+// radiobutton and category fields.
+func (v CustomFieldValue) EnumIDs() []int {
+	ids := make([]int, 0, len(v.Values))
+	for _, item := range v.Values {
+		if item.EnumID != 0 {
+			ids = append(ids, item.EnumID)
+		}
+	}
+
+	return ids
+}
+
+// CustomFieldsValues are custom field values of an entity such as Lead,
+// Contact or Company.
+type CustomFieldsValues []CustomFieldValue
+
+// ByID returns values of the field with given ID or nil.
+func (c CustomFieldsValues) ByID(id int) *CustomFieldValue {
+	for i := range c {
+		if c[i].FieldID == id {
+			return &c[i]
+		}
+	}
+
+	return nil
+}
+
+// ByCode returns values of the field with given code, e.g. PHONE, or nil.
+func (c CustomFieldsValues) ByCode(code string) *CustomFieldValue {
+	for i := range c {
+		if c[i].FieldCode == code {
+			return &c[i]
+		}
+	}
+
+	return nil
+}
+
+// Set replaces values of the field identified by v.FieldID or v.FieldCode
+// or appends them. Set v.Clear to erase the field.
+func (c *CustomFieldsValues) Set(v CustomFieldValue) {
+	for i, field := range *c {
+		if v.FieldID != 0 && field.FieldID == v.FieldID ||
+			v.FieldID == 0 && v.FieldCode != "" && field.FieldCode == v.FieldCode {
+			(*c)[i] = v
+			return
+		}
+	}
+
+	*c = append(*c, v)
+}
+
+// SetByID replaces values of the field with given ID.
+func (c *CustomFieldsValues) SetByID(id int, values ...CustomFieldItem) {
+	c.Set(CustomFieldValue{FieldID: id, Values: values})
+}
+
+// SetByCode replaces values of the field with given code.
+func (c *CustomFieldsValues) SetByCode(code string, values ...CustomFieldItem) {
+	c.Set(CustomFieldValue{FieldCode: code, Values: values})
+}
+
+// ClearByID erases the field with given ID.
+func (c *CustomFieldsValues) ClearByID(id int) {
+	c.Set(CustomFieldValue{FieldID: id, Clear: true})
+}
+
+// ClearByCode erases the field with given code.
+func (c *CustomFieldsValues) ClearByCode(code string) {
+	c.Set(CustomFieldValue{FieldCode: code, Clear: true})
+}
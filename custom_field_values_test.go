@@ -0,0 +1,127 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ros-tel/amocrm"
+)
+
+const contactJSON = `{
+	"id": 1,
+	"custom_fields_values": [
+		{"field_id": 10, "field_name": "Телефон", "field_code": "PHONE", "field_type": "multitext", "values": [
+			{"value": "+79000000000", "enum_id": 1, "enum_code": "WORK"},
+			{"value": "+79111111111", "enum_id": 3, "enum_code": "MOB"}
+		]},
+		{"field_id": 11, "field_name": "Бюджет", "field_type": "numeric", "values": [{"value": "1500.50"}]},
+		{"field_id": 12, "field_name": "День рождения", "field_type": "birthday", "values": [{"value": 1609459200}]},
+		{"field_id": 13, "field_name": "Интересы", "field_type": "multiselect", "values": [
+			{"value": "Спорт", "enum_id": 21},
+			{"value": "Кино", "enum_id": 22}
+		]},
+		{"field_id": 14, "field_name": "VIP", "field_type": "checkbox", "values": [{"value": true}]},
+		{"field_id": 15, "field_name": "Юрлицо", "field_type": "legal_entity", "values": [{"value": {"name": "ООО Ромашка", "vat_id": "7700000000"}}]}
+	]
+}`
+
+func TestCustomFieldsValues_Unmarshal(t *testing.T) {
+	var contact amocrm.Contact
+	require.NoError(t, json.Unmarshal([]byte(contactJSON), &contact))
+
+	phone := contact.CustomFieldsValues.ByCode("PHONE")
+	require.NotNil(t, phone)
+	require.Equal(t, amocrm.FieldTypeMultitext, phone.FieldType)
+	require.Equal(t, "+79000000000", phone.AsString())
+	require.Equal(t, []string{"+79000000000", "+79111111111"}, phone.Strings())
+	require.Equal(t, amocrm.EnumMob, phone.Values[1].EnumCode)
+
+	price, err := contact.CustomFieldsValues.ByID(11).AsFloat()
+	require.NoError(t, err)
+	require.Equal(t, 1500.5, price)
+
+	birthday, err := contact.CustomFieldsValues.ByID(12).AsTime()
+	require.NoError(t, err)
+	require.True(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).Equal(birthday))
+
+	require.Equal(t, []int{21, 22}, contact.CustomFieldsValues.ByID(13).EnumIDs())
+
+	vip, err := contact.CustomFieldsValues.ByID(14).AsBool()
+	require.NoError(t, err)
+	require.True(t, vip)
+
+	var entity amocrm.LegalEntity
+	require.NoError(t, contact.CustomFieldsValues.ByID(15).Decode(&entity))
+	require.Equal(t, amocrm.LegalEntity{Name: "ООО Ромашка", VatID: "7700000000"}, entity)
+
+	require.Nil(t, contact.CustomFieldsValues.ByID(99))
+}
+
+func TestCustomFieldsValues_Set(t *testing.T) {
+	var values amocrm.CustomFieldsValues
+	values.SetByCode("PHONE", amocrm.MultitextValue(amocrm.EnumWork, "+79000000000"))
+	budget, err := amocrm.NumericValue(100)
+	require.NoError(t, err)
+	values.SetByID(11, budget)
+	values.SetByID(12, amocrm.TimeValue(time.Unix(1609459200, 0)))
+	values.SetByID(13, amocrm.EnumValue(21), amocrm.EnumValue(22))
+	values.SetByID(14, amocrm.BoolValue(false))
+
+	items, err := amocrm.ObjectValue(amocrm.ItemsValue{SKU: "A1", UnitPrice: 10, Quantity: 2})
+	require.NoError(t, err)
+	values.SetByID(16, items)
+
+	// Replace existing values and clear a field.
+	values.SetByCode("PHONE", amocrm.MultitextValue(amocrm.EnumMob, "+79111111111"))
+	values.ClearByID(14)
+
+	data, err := json.Marshal(amocrm.Lead{CustomFieldsValues: values})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"custom_fields_values": [
+		{"field_code": "PHONE", "values": [{"value": "+79111111111", "enum_code": "MOB"}]},
+		{"field_id": 11, "values": [{"value": 100}]},
+		{"field_id": 12, "values": [{"value": 1609459200}]},
+		{"field_id": 13, "values": [{"enum_id": 21}, {"enum_id": 22}]},
+		{"field_id": 14, "values": null},
+		{"field_id": 16, "values": [{"value": {"sku": "A1", "unit_price": 10, "quantity": 2}}]}
+	]}`, string(data))
+}
+
+func TestCustomFieldValue_Marshal(t *testing.T) {
+	data, err := json.Marshal(amocrm.CustomFieldValue{FieldID: 1})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"field_id": 1}`, string(data))
+
+	data, err = json.Marshal(amocrm.CustomFieldValue{FieldCode: "PHONE", Clear: true, Values: []amocrm.CustomFieldItem{amocrm.TextValue("+79000000000")}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"field_code": "PHONE", "values": null}`, string(data))
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := amocrm.NumericValue(f)
+		require.Error(t, err)
+	}
+}
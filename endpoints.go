@@ -24,20 +24,29 @@ package amocrm
 
 import (
 	"fmt"
+	"strings"
 )
 
+// endpoint is a path relative to /api/v4/. Endpoints of other API
+// versions are absolute paths starting with a slash.
 type endpoint string
 
 func (e endpoint) path() string {
-	if e == eventsV2endpoint {
-		return fmt.Sprintf("/api/v2/%s/", e)
+	if strings.HasPrefix(string(e), "/") {
+		return string(e)
 	}
 
 	return fmt.Sprintf("/api/v%d/%s", apiVersion, e)
 }
 
+// id returns the endpoint of a single entity.
+func (e endpoint) id(id int) endpoint {
+	return endpoint(fmt.Sprintf("%s/%d", e, id))
+}
+
 const (
-	accountsEndpoint endpoint = "accounts"
-	leadsEndpoint endpoint = "leads"
-	contactsEndpoint endpoint = "contacts"
+	accountsEndpoint  endpoint = "accounts"
+	leadsEndpoint     endpoint = "leads"
+	contactsEndpoint  endpoint = "contacts"
+	companiesEndpoint endpoint = "companies"
 )
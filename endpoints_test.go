@@ -35,3 +35,9 @@ func TestEndpoint_Path(t *testing.T) {
 	require.Contains(t, path, "/api/v")
 	require.Contains(t, path, "/example")
 }
+
+func TestEndpoint_PathVersions(t *testing.T) {
+	require.Equal(t, "/api/v4/events", eventsEndpoint.path())
+	require.Equal(t, "/api/v4/events/types", (eventsEndpoint + "/types").path())
+	require.Equal(t, "/api/v2/events/", eventsV2endpoint.path())
+}
@@ -22,8 +22,29 @@
 
 package amocrm
 
+import "fmt"
+
 type FieldValues map[string]interface{}
 
+// EntityType selects the entity of repositories shared by several
+// entities, e.g. custom fields, notes and tags.
+type EntityType string
+
+const (
+	EntityLeads     EntityType = "leads"
+	EntityContacts  EntityType = "contacts"
+	EntityCompanies EntityType = "companies"
+	EntityCustomers EntityType = "customers"
+
+	// EntityCatalogElements is only used as a target of entity links.
+	EntityCatalogElements EntityType = "catalog_elements"
+)
+
+// CatalogEntity returns EntityType of elements of the catalog.
+func CatalogEntity(catalogID int) EntityType {
+	return EntityType(fmt.Sprintf("catalogs/%d", catalogID))
+}
+
 // Account represents amoCRM Account entity json DTO.
 type Account struct {
 	ID                      int    `json:"id"`
@@ -61,13 +82,7 @@ type Account struct {
 			Name string      `json:"name"`
 			UUID interface{} `json:"uuid"`
 		} `json:"users_groups"`
-		TaskTypes []struct {
-			ID     int         `json:"id"`
-			Name   string      `json:"name"`
-			Color  interface{} `json:"color"`
-			IconID interface{} `json:"icon_id"`
-			Code   string      `json:"code"`
-		} `json:"task_types"`
+		TaskTypes        []TaskType `json:"task_types"`
 		DatetimeSettings struct {
 			DatePattern      string `json:"date_pattern"`
 			ShortDatePattern string `json:"short_date_pattern"`
@@ -79,3 +94,54 @@ type Account struct {
 		} `json:"datetime_settings"`
 	} `json:"_embedded"`
 }
+
+// TaskType is a type of tasks available in the account.
+type TaskType struct {
+	ID     int         `json:"id"`
+	Name   string      `json:"name"`
+	Color  interface{} `json:"color"`
+	IconID interface{} `json:"icon_id"`
+	Code   string      `json:"code"`
+}
+
+// EmbeddedContact is a contact linked to another entity.
+type EmbeddedContact struct {
+	Id     int  `json:"id"`
+	IsMain bool `json:"is_main,omitempty"` //Является ли контакт главным для сделки
+}
+
+// EmbeddedCompany is a company linked to another entity.
+type EmbeddedCompany struct {
+	Id int `json:"id"`
+}
+
+// EmbeddedLead is a lead linked to another entity.
+type EmbeddedLead struct {
+	Id int `json:"id"`
+}
+
+// EmbeddedCustomer is a customer linked to another entity.
+type EmbeddedCustomer struct {
+	Id int `json:"id"`
+}
+
+// EmbeddedCatalogElement is a catalog element linked to another entity.
+type EmbeddedCatalogElement struct {
+	Id       int                     `json:"id"`
+	Metadata *CatalogElementMetadata `json:"metadata,omitempty"`
+}
+
+type CatalogElementMetadata struct {
+	Quantity  float64 `json:"quantity,omitempty"`   //Количество элементов у сущности
+	CatalogId int     `json:"catalog_id,omitempty"` //ID списка
+	PriceId   int     `json:"price_id,omitempty"`   //ID поля типа Цена, которое будет установлено для привязанного элемента в контексте сущности
+}
+
+// LossReason is a reason a lead was lost for.
+type LossReason struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	Sort      int    `json:"sort"`
+	CreatedAt int    `json:"created_at"`
+	UpdatedAt int    `json:"updated_at"`
+}
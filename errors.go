@@ -0,0 +1,139 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Sentinel errors matched by APIError depending on the response status.
+// Use errors.Is to check which one an error returned by a repository is.
+var (
+	ErrBadRequest      = errors.New("bad request")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrPaymentRequired = errors.New("account is not paid")
+	ErrAccountBlocked  = errors.New("account is blocked")
+	ErrNotFound        = errors.New("not found")
+	ErrRateLimited     = errors.New("rate limit exceeded")
+	ErrServerError     = errors.New("server error")
+)
+
+// ErrReauthorizationRequired is returned when amoCRM rejects the refresh
+// token. The user has to grant access again via Client.AuthorizeURL.
+var ErrReauthorizationRequired = errors.New("reauthorization required")
+
+// ErrCustomersDisabled is returned by Customers repository when customers
+// are not turned on in the account, see Account.CustomersMode.
+var ErrCustomersDisabled = errors.New("customers are disabled")
+
+// APIError is returned for every amoCRM API response with status >= 400.
+// It is decoded from the application/problem+json body when there is one.
+type APIError struct {
+	Status           int               `json:"status"`
+	Title            string            `json:"title"`
+	Detail           string            `json:"detail"`
+	Type             string            `json:"type"`
+	RequestID        string            `json:"request_id"`
+	ValidationErrors []ValidationError `json:"validation-errors"`
+}
+
+// ValidationError describes the fields of a single entity of a batch
+// request that failed validation.
+type ValidationError struct {
+	RequestID string       `json:"request_id"`
+	Errors    []FieldError `json:"errors"`
+}
+
+// FieldError is a validation failure of a single field.
+type FieldError struct {
+	Code   string `json:"code"`
+	Path   string `json:"path"`
+	Detail string `json:"detail"`
+}
+
+// Index returns the position of the failed entity in the request batch.
+// amoCRM uses the position as request_id unless the caller has set one,
+// so ok is false when request_id is not a number.
+func (e ValidationError) Index() (index int, ok bool) {
+	index, err := strconv.Atoi(e.RequestID)
+	return index, err == nil
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid status: %d", e.Status)
+	if e.Title != "" {
+		fmt.Fprintf(&b, " %s", e.Title)
+	}
+	if e.Detail != "" {
+		fmt.Fprintf(&b, ": %s", e.Detail)
+	}
+	for _, ve := range e.ValidationErrors {
+		for _, fe := range ve.Errors {
+			fmt.Fprintf(&b, "; request_id %s: %s: %s", ve.RequestID, fe.Path, fe.Code)
+		}
+	}
+
+	return b.String()
+}
+
+// Is reports whether target is the sentinel error matching e.Status.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.Status == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrPaymentRequired:
+		return e.Status == http.StatusPaymentRequired
+	case ErrAccountBlocked:
+		return e.Status == http.StatusForbidden
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.Status >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// newAPIError builds an APIError from the response status and body.
+// Bodies that are not valid JSON end up in Detail.
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		apiErr = &APIError{Detail: strings.TrimSpace(string(body))}
+	}
+
+	apiErr.Status = status
+	if apiErr.Title == "" {
+		apiErr.Title = http.StatusText(status)
+	}
+
+	return apiErr
+}
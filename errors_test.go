@@ -0,0 +1,92 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Validation(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{
+			"validation-errors": [{
+				"request_id": "1",
+				"errors": [{"code": "NotSupportedChoice", "path": "custom_fields_values.0.field_id", "detail": "The value you selected is not a valid choice."}]
+			}],
+			"title": "Bad Request",
+			"type": "https://httpstatus.es/400",
+			"status": 400,
+			"detail": "Request validation failed"
+		}`))
+	}))
+
+	_, err := leads{api: a}.CreateContext(context.Background(), []Lead{{}, {}})
+	require.True(t, errors.Is(err, ErrBadRequest))
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.Status)
+	require.Equal(t, "Request validation failed", apiErr.Detail)
+	require.Len(t, apiErr.ValidationErrors, 1)
+
+	index, ok := apiErr.ValidationErrors[0].Index()
+	require.True(t, ok)
+	require.Equal(t, 1, index)
+	require.Equal(t, "custom_fields_values.0.field_id", apiErr.ValidationErrors[0].Errors[0].Path)
+}
+
+func TestAPIError_Sentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		target error
+	}{
+		{status: http.StatusUnauthorized, body: `{"title":"Unauthorized","status":401}`, target: ErrUnauthorized},
+		{status: http.StatusPaymentRequired, body: `{"title":"Payment Required","status":402}`, target: ErrPaymentRequired},
+		{status: http.StatusForbidden, body: `{"title":"Forbidden","status":403}`, target: ErrAccountBlocked},
+		{status: http.StatusNotFound, body: ``, target: ErrNotFound},
+		{status: http.StatusTooManyRequests, body: ``, target: ErrRateLimited},
+		{status: http.StatusBadGateway, body: `<html>bad gateway</html>`, target: ErrServerError},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/oauth2/access_token" {
+				_, _ = w.Write([]byte(`{"token_type":"Bearer","expires_in":86400,"access_token":"a","refresh_token":"r"}`))
+				return
+			}
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(tc.body))
+		}))
+
+		_, err := accounts{api: a}.CurrentContext(context.Background(), AccountsConfig{})
+		require.True(t, errors.Is(err, tc.target), err)
+		require.False(t, errors.Is(err, ErrBadRequest), err)
+	}
+}
@@ -24,7 +24,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/sintanial/amocrm"
+	"github.com/ros-tel/amocrm"
 )
 
 var (
@@ -0,0 +1,276 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FieldRegistry loads custom field definitions of the account once per
+// entity type and resolves fields by ID, code or name. It is safe for
+// concurrent use.
+type FieldRegistry struct {
+	client Client
+
+	mu      sync.RWMutex
+	fields  map[EntityType][]CustomField
+	loading map[EntityType]*fieldsLoad
+}
+
+// fieldsLoad is an in-flight first load of fields of an entity shared by
+// concurrent callers.
+type fieldsLoad struct {
+	done chan struct{}
+	err  error
+}
+
+// NewFieldRegistry returns an empty registry loading definitions with client.
+func NewFieldRegistry(client Client) *FieldRegistry {
+	return &FieldRegistry{
+		client:  client,
+		fields:  make(map[EntityType][]CustomField),
+		loading: make(map[EntityType]*fieldsLoad),
+	}
+}
+
+// Fields returns a copy of all custom fields of the entity, loading them
+// on first use.
+func (r *FieldRegistry) Fields(ctx context.Context, entity EntityType) ([]CustomField, error) {
+	fields, err := r.cached(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := make([]CustomField, len(fields))
+	for i := range fields {
+		copied[i] = fields[i].clone()
+	}
+
+	return copied, nil
+}
+
+// cached returns fields of the entity shared by all callers, which must
+// not modify them. Concurrent callers wait for the same first load.
+func (r *FieldRegistry) cached(ctx context.Context, entity EntityType) ([]CustomField, error) {
+	r.mu.RLock()
+	fields, ok := r.fields[entity]
+	r.mu.RUnlock()
+	if ok {
+		return fields, nil
+	}
+
+	for {
+		r.mu.Lock()
+		if fields, ok := r.fields[entity]; ok {
+			r.mu.Unlock()
+			return fields, nil
+		}
+
+		load := r.loading[entity]
+		if load == nil {
+			load = &fieldsLoad{done: make(chan struct{})}
+			r.loading[entity] = load
+			r.mu.Unlock()
+
+			load.err = r.Refresh(ctx, entity)
+
+			r.mu.Lock()
+			delete(r.loading, entity)
+			r.mu.Unlock()
+			close(load.done)
+
+			if load.err != nil {
+				return nil, load.err
+			}
+			continue
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-load.done:
+		}
+
+		// The caller that started the load has given up, try again
+		// with our own context.
+		if load.err != nil && !errors.Is(load.err, context.Canceled) && !errors.Is(load.err, context.DeadlineExceeded) {
+			return nil, load.err
+		}
+	}
+}
+
+// Refresh reloads custom fields of the entity, e.g. after they have
+// been changed in amoCRM interface.
+func (r *FieldRegistry) Refresh(ctx context.Context, entity EntityType) error {
+	fields, err := Collect(ctx, r.client.CustomFields(entity).Iterate(CustomFieldsFilter{Limit: 50}))
+	if err != nil {
+		return fmt.Errorf("load %s custom fields: %w", entity, err)
+	}
+
+	r.mu.Lock()
+	r.fields[entity] = fields
+	r.mu.Unlock()
+
+	return nil
+}
+
+// ByID returns the custom field of the entity with given ID.
+func (r *FieldRegistry) ByID(ctx context.Context, entity EntityType, id int) (*CustomField, error) {
+	return r.find(ctx, entity, fmt.Sprintf("id %d", id), func(f CustomField) bool {
+		return f.ID == id
+	})
+}
+
+// ByCode returns the custom field of the entity with given code, e.g. PHONE.
+func (r *FieldRegistry) ByCode(ctx context.Context, entity EntityType, code string) (*CustomField, error) {
+	return r.find(ctx, entity, "code "+code, func(f CustomField) bool {
+		return f.Code == code
+	})
+}
+
+// ByName returns the custom field of the entity with given name ignoring case.
+func (r *FieldRegistry) ByName(ctx context.Context, entity EntityType, name string) (*CustomField, error) {
+	return r.find(ctx, entity, "name "+name, func(f CustomField) bool {
+		return strings.EqualFold(f.Name, name)
+	})
+}
+
+func (r *FieldRegistry) find(ctx context.Context, entity EntityType, key string, match func(CustomField) bool) (*CustomField, error) {
+	fields, err := r.cached(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range fields {
+		if match(fields[i]) {
+			field := fields[i].clone()
+			return &field, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s custom field with %s: %w", entity, key, ErrNotFound)
+}
+
+// Validate checks that every field of values exists for the entity and
+// that enum values are among options of the field.
+func (r *FieldRegistry) Validate(ctx context.Context, entity EntityType, values CustomFieldsValues) error {
+	for _, value := range values {
+		var (
+			field *CustomField
+			err   error
+		)
+		switch {
+		case value.FieldID != 0:
+			field, err = r.ByID(ctx, entity, value.FieldID)
+		case value.FieldCode != "":
+			field, err = r.ByCode(ctx, entity, value.FieldCode)
+		default:
+			return fmt.Errorf("%s custom field value without field_id and field_code", entity)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := field.validate(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f CustomField) validate(value CustomFieldValue) error {
+	if len(f.Enums) == 0 {
+		return nil
+	}
+
+	for _, item := range value.Values {
+		switch {
+		case item.EnumID != 0:
+			if _, ok := f.EnumByID(item.EnumID); !ok {
+				return fmt.Errorf("custom field %d %q: unexpected enum_id %d", f.ID, f.Name, item.EnumID)
+			}
+		case item.EnumCode != "":
+			if _, ok := f.EnumByCode(item.EnumCode); !ok {
+				return fmt.Errorf("custom field %d %q: unexpected enum_code %s", f.ID, f.Name, item.EnumCode)
+			}
+		case f.Type != FieldTypeMultitext && len(item.Value) != 0:
+			if _, ok := f.EnumByValue(item.AsString()); !ok {
+				return fmt.Errorf("custom field %d %q: unexpected value %s", f.ID, f.Name, item.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EnumByID returns the option of select-like field with given ID.
+func (f CustomField) EnumByID(id int) (CustomFieldEnum, bool) {
+	for _, enum := range f.Enums {
+		if enum.ID == id {
+			return enum, true
+		}
+	}
+
+	return CustomFieldEnum{}, false
+}
+
+// EnumByCode returns the option of a multitext field such as PHONE with
+// given enum_code. amoCRM keeps enum codes, e.g. WORK or MOB, as values
+// of the options of these fields.
+func (f CustomField) EnumByCode(code string) (CustomFieldEnum, bool) {
+	return f.EnumByValue(code)
+}
+
+// EnumByValue returns the option of select-like field with given value.
+func (f CustomField) EnumByValue(value string) (CustomFieldEnum, bool) {
+	for _, enum := range f.Enums {
+		if enum.Value == value {
+			return enum, true
+		}
+	}
+
+	return CustomFieldEnum{}, false
+}
+
+// clone returns a copy of f that shares no slices with it.
+func (f CustomField) clone() CustomField {
+	f.Settings = cloneSlice(f.Settings)
+	f.Enums = cloneSlice(f.Enums)
+	f.Nested = cloneSlice(f.Nested)
+	f.RequiredStatuses = cloneSlice(f.RequiredStatuses)
+	f.HiddenStatuses = cloneSlice(f.HiddenStatuses)
+
+	return f
+}
+
+func cloneSlice[S ~[]E, E any](s S) S {
+	if s == nil {
+		return nil
+	}
+
+	return append(make(S, 0, len(s)), s...)
+}
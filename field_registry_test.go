@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldRegistry(t *testing.T) {
+	var loads int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/contacts/custom_fields", r.URL.Path)
+		atomic.AddInt32(&loads, 1)
+		_, _ = w.Write([]byte(`{"_embedded": {"custom_fields": [
+			{"id": 1, "name": "Телефон", "code": "PHONE", "type": "multitext", "enums": [
+				{"id": 11, "value": "WORK"}, {"id": 12, "value": "MOB"}
+			]},
+			{"id": 2, "name": "Источник", "type": "select", "enums": [
+				{"id": 21, "value": "Сайт"}, {"id": 22, "value": "Звонок"}
+			]},
+			{"id": 3, "name": "Комментарий", "type": "textarea"}
+		]}}`))
+	}))
+	registry := NewFieldRegistry(&amoCRM{api: a})
+	ctx := context.Background()
+
+	phone, err := registry.ByCode(ctx, EntityContacts, "PHONE")
+	require.NoError(t, err)
+	require.Equal(t, 1, phone.ID)
+
+	source, err := registry.ByName(ctx, EntityContacts, "источник")
+	require.NoError(t, err)
+	require.Equal(t, FieldTypeSelect, source.Type)
+
+	_, err = registry.ByID(ctx, EntityContacts, 4)
+	require.True(t, errors.Is(err, ErrNotFound), err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&loads))
+
+	var values CustomFieldsValues
+	values.SetByCode("PHONE", MultitextValue(EnumMob, "+79000000000"))
+	values.SetByID(2, EnumValue(22))
+	values.SetByID(3, TextValue("Перезвонить"))
+	require.NoError(t, registry.Validate(ctx, EntityContacts, values))
+
+	values.SetByID(2, EnumValue(23))
+	require.EqualError(t, registry.Validate(ctx, EntityContacts, values), `custom field 2 "Источник": unexpected enum_id 23`)
+
+	values.SetByID(2, TextValue("Реклама"))
+	require.EqualError(t, registry.Validate(ctx, EntityContacts, values), `custom field 2 "Источник": unexpected value "Реклама"`)
+
+	values.SetByCode("PHONE", MultitextValue("PAGER", "+79000000000"))
+	require.Error(t, registry.Validate(ctx, EntityContacts, values))
+
+	loaded := atomic.LoadInt32(&loads)
+	err = registry.Validate(ctx, EntityContacts, CustomFieldsValues{{Values: []CustomFieldItem{TextValue("Перезвонить")}}})
+	require.EqualError(t, err, "contacts custom field value without field_id and field_code")
+	require.Equal(t, loaded, atomic.LoadInt32(&loads))
+
+	require.NoError(t, registry.Refresh(ctx, EntityContacts))
+	require.EqualValues(t, 2, atomic.LoadInt32(&loads))
+}
+
+func TestCustomFields_Groups(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v4/leads/custom_fields/groups":
+			_, _ = w.Write([]byte(`{"_embedded": {"custom_field_groups": [{"id": "leads_1", "name": "Доставка"}]}}`))
+		case "DELETE /api/v4/leads/custom_fields/groups/leads_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	fields := customFields{api: a, entity: EntityLeads}
+	groups, err := fields.ListGroups(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []CustomFieldGroup{{ID: "leads_1", Name: "Доставка"}}, groups)
+	require.NoError(t, fields.DeleteGroup(context.Background(), "leads_1"))
+}
+
+func TestFieldRegistry_ConcurrentFirstLoad(t *testing.T) {
+	var loads int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&loads, 1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"_embedded": {"custom_fields": [
+			{"id": 2, "name": "Источник", "type": "select", "enums": [{"id": 21, "value": "Сайт"}]}
+		]}}`))
+	}))
+	registry := NewFieldRegistry(&amoCRM{api: a})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := registry.Fields(context.Background(), EntityLeads)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&loads))
+
+	// Changes of returned fields do not leak into the registry.
+	fields, err := registry.Fields(context.Background(), EntityLeads)
+	require.NoError(t, err)
+	fields[0].Name = "Канал"
+	fields[0].Enums[0].Value = "Звонок"
+
+	field, err := registry.ByID(context.Background(), EntityLeads, 2)
+	require.NoError(t, err)
+	require.Equal(t, "Источник", field.Name)
+	require.Equal(t, []CustomFieldEnum{{ID: 21, Value: "Сайт"}}, field.Enums)
+}
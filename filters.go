@@ -0,0 +1,126 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Order directions for list requests.
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// Order sorts list results by Field in Direction (OrderAsc or OrderDesc).
+type Order struct {
+	Field     string
+	Direction string
+}
+
+// Range is an interval filter, e.g. by price or by Unix timestamps.
+// Zero bounds are not sent.
+type Range struct {
+	From int
+	To   int
+}
+
+// StatusFilter selects entities in a status of a pipeline.
+type StatusFilter struct {
+	PipelineID int
+	StatusID   int
+}
+
+// addRelations sets relations as a single comma-separated with parameter,
+// the only form amoCRM reads all of them from.
+func addRelations(q url.Values, entity string, relations []string, allowed ...string) error {
+	for _, relation := range relations {
+		if !contains(allowed, relation) {
+			return fmt.Errorf("unexpected %s relation: %s", entity, relation)
+		}
+	}
+	if len(relations) != 0 {
+		q.Set("with", strings.Join(relations, ","))
+	}
+
+	return nil
+}
+
+func addInts(q url.Values, key string, values []int) {
+	for _, v := range values {
+		q.Add(key+"[]", strconv.Itoa(v))
+	}
+}
+
+func addStrings(q url.Values, key string, values []string) {
+	for _, v := range values {
+		q.Add(key+"[]", v)
+	}
+}
+
+func addRange(q url.Values, key string, r *Range) {
+	if r == nil {
+		return
+	}
+	if r.From != 0 {
+		q.Set(key+"[from]", strconv.Itoa(r.From))
+	}
+	if r.To != 0 {
+		q.Set(key+"[to]", strconv.Itoa(r.To))
+	}
+}
+
+func addOrder(q url.Values, order *Order, fields ...string) error {
+	if order == nil {
+		return nil
+	}
+	if !contains(fields, order.Field) {
+		return fmt.Errorf("unexpected order field: %s", order.Field)
+	}
+	if order.Direction != OrderAsc && order.Direction != OrderDesc {
+		return fmt.Errorf("unexpected order direction: %s", order.Direction)
+	}
+	q.Set("order["+order.Field+"]", order.Direction)
+
+	return nil
+}
+
+func addPage(q url.Values, page, limit int) {
+	if page > 0 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
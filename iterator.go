@@ -0,0 +1,190 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Iterator walks entities of a list request page by page, requesting the
+// next page number for as long as amoCRM responses have _links.next.
+// Only one page is kept in memory.
+//
+//	it := client.Leads().Iterate(amocrm.LeadsFilter{Limit: 250})
+//	for it.Next(ctx) {
+//		lead := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	fetch func(ctx context.Context, page int) ([]T, bool, error)
+
+	next    int // page to fetch next
+	current int // page of the current value
+	items   []T
+	index   int
+	value   T
+	last    bool
+	err     error
+}
+
+func newIterator[T any](page int, fetch func(ctx context.Context, page int) ([]T, bool, error)) *Iterator[T] {
+	if page < 1 {
+		page = 1
+	}
+
+	return &Iterator[T]{fetch: fetch, next: page}
+}
+
+// Next advances the iterator to the next entity, fetching the next page
+// when the current one is exhausted. It returns false when there are no
+// more entities or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.items) {
+		if it.last || it.err != nil {
+			return false
+		}
+
+		items, hasNext, err := it.fetch(ctx, it.next)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items, it.index = items, 0
+		it.current = it.next
+		it.next++
+		it.last = !hasNext
+	}
+
+	it.value = it.items[it.index]
+	it.index++
+
+	return true
+}
+
+// Value returns the entity Next has advanced to.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any. After Chan,
+// call it only once the channel is closed.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Page returns the number of the page the current value belongs to.
+// Pass it as the filter page to resume the iteration later.
+func (it *Iterator[T]) Page() int {
+	return it.current
+}
+
+// Chan sends the remaining entities to the returned channel and closes
+// it when the iteration is over or ctx is done. To stop reading early,
+// cancel ctx: otherwise the sending goroutine blocks forever. Err and
+// the other methods of it may only be called once the channel is closed,
+// as the goroutine updates it until then.
+func (it *Iterator[T]) Chan(ctx context.Context) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for it.Next(ctx) {
+			select {
+			case ch <- it.Value():
+			case <-ctx.Done():
+				it.err = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// Collect reads all the remaining entities of it into a slice.
+func Collect[T any](ctx context.Context, it *Iterator[T]) ([]T, error) {
+	var all []T
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+
+	return all, it.Err()
+}
+
+// iterate returns an Iterator over pages of ep starting from page.
+// A non-nil qErr, e.g. an invalid filter, is reported by Err.
+func iterate[T any](a *api, ep endpoint, key string, page int, q url.Values, qErr error) *Iterator[T] {
+	it := newIterator(page, func(ctx context.Context, page int) ([]T, bool, error) {
+		return listPage[T](ctx, a, ep, withPage(q, page), key)
+	})
+	it.err = qErr
+
+	return it
+}
+
+// listPage fetches a single page of a list endpoint and decodes entities
+// found in the _embedded[key] of the response. It reports whether there
+// is a next page.
+func listPage[T any](ctx context.Context, a *api, ep endpoint, q url.Values, key string) ([]T, bool, error) {
+	resp, err := a.do(ctx, ep, http.MethodGet, q, nil, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	var res struct {
+		Links struct {
+			Next *struct {
+				Href string `json:"href"`
+			} `json:"next"`
+		} `json:"_links"`
+		Embedded map[string]json.RawMessage `json:"_embedded"`
+	}
+	if err := a.read(resp, &res); err != nil {
+		return nil, false, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	var items []T
+	if raw, ok := res.Embedded[key]; ok {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, false, fmt.Errorf("get %s: %w", key, err)
+		}
+	}
+
+	return items, res.Links.Next != nil, nil
+}
+
+// withPage returns a copy of q requesting the given page.
+func withPage(q url.Values, page int) url.Values {
+	paged := url.Values{}
+	for k, v := range q {
+		paged[k] = v
+	}
+	paged.Set("page", strconv.Itoa(page))
+
+	return paged
+}
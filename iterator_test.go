@@ -0,0 +1,104 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedLeads serves 3 pages of 2 leads each.
+func pagedLeads(t *testing.T, fetched *int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(fetched, 1)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		next := ""
+		if page < 3 {
+			next = fmt.Sprintf(`"next": {"href": "https://example.amocrm.ru/api/v4/leads?page=%d&limit=2"},`, page+1)
+		}
+		_, _ = fmt.Fprintf(w, `{"_page": %d, "_links": {%s "self": {"href": ""}}, "_embedded": {"leads": [{"id": %d}, {"id": %d}]}}`,
+			page, next, page*10+1, page*10+2)
+	})
+}
+
+func TestIterator_Collect(t *testing.T) {
+	var fetched int32
+	a := newTestAPI(t, pagedLeads(t, &fetched))
+
+	all, err := Collect(context.Background(), leads{api: a}.Iterate(LeadsFilter{Limit: 2}))
+	require.NoError(t, err)
+	require.Equal(t, []Lead{{Id: 11}, {Id: 12}, {Id: 21}, {Id: 22}, {Id: 31}, {Id: 32}}, all)
+	require.EqualValues(t, 3, fetched)
+}
+
+func TestIterator_ResumeAndStop(t *testing.T) {
+	var fetched int32
+	a := newTestAPI(t, pagedLeads(t, &fetched))
+
+	it := leads{api: a}.Iterate(LeadsFilter{Limit: 2, Page: 2})
+	require.True(t, it.Next(context.Background()))
+	require.Equal(t, 21, it.Value().Id)
+	require.Equal(t, 2, it.Page())
+	require.True(t, it.Next(context.Background()))
+	require.True(t, it.Next(context.Background()))
+	require.Equal(t, 31, it.Value().Id)
+	require.Equal(t, 3, it.Page())
+	require.NoError(t, it.Err())
+	require.EqualValues(t, 2, fetched)
+}
+
+func TestIterator_Chan(t *testing.T) {
+	var fetched int32
+	a := newTestAPI(t, pagedLeads(t, &fetched))
+
+	it := leads{api: a}.Iterate(LeadsFilter{Limit: 2})
+	var ids []int
+	for lead := range it.Chan(context.Background()) {
+		ids = append(ids, lead.Id)
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []int{11, 12, 21, 22, 31, 32}, ids)
+}
+
+func TestIterator_Err(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+
+	it := leads{api: a}.Iterate(LeadsFilter{})
+	require.False(t, it.Next(context.Background()))
+	require.True(t, errors.Is(it.Err(), ErrPaymentRequired))
+
+	it = leads{api: a}.Iterate(LeadsFilter{Relations: []string{"example"}})
+	require.False(t, it.Next(context.Background()))
+	require.EqualError(t, it.Err(), "unexpected lead relation: example")
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is the number of requests per second amoCRM allows
+// an integration to make to a single account.
+const DefaultRateLimit = 7
+
+// RateLimiter throttles requests to amoCRM API.
+//
+// Wait blocks until a request to the account at domain is allowed or ctx
+// is done. Implement it on top of a shared store to coordinate several
+// processes talking to the same account.
+type RateLimiter interface {
+	Wait(ctx context.Context, domain string) error
+}
+
+// tokenBucketLimiter implements RateLimiter with an in-memory token
+// bucket per domain.
+type tokenBucketLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Verify interface compliance.
+var _ RateLimiter = (*tokenBucketLimiter)(nil)
+
+// NewRateLimiter returns a RateLimiter allowing rps requests per second
+// with bursts of up to burst requests to every domain.
+func NewRateLimiter(rps float64, burst int) RateLimiter {
+	if rps <= 0 {
+		rps = DefaultRateLimit
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucketLimiter{
+		rate:    rps,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (l *tokenBucketLimiter) Wait(ctx context.Context, domain string) error {
+	for {
+		delay := l.reserve(domain)
+		if delay == 0 {
+			return nil
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token from the domain bucket and returns zero,
+// or returns how long to wait until a token is available.
+func (l *tokenBucketLimiter) reserve(domain string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[domain]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[domain] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	delay := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	if delay < time.Millisecond {
+		delay = time.Millisecond
+	}
+
+	return delay
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	limiter := NewRateLimiter(20, 2).(*tokenBucketLimiter)
+	limiter.now = func() time.Time { return now }
+
+	// Two requests fit in the burst, the next one waits for a token.
+	require.Zero(t, limiter.reserve("a.amocrm.ru"))
+	require.Zero(t, limiter.reserve("a.amocrm.ru"))
+	require.InDelta(t, 50*time.Millisecond, limiter.reserve("a.amocrm.ru"), float64(time.Microsecond))
+
+	now = now.Add(50 * time.Millisecond)
+	require.Zero(t, limiter.reserve("a.amocrm.ru"))
+	require.NotZero(t, limiter.reserve("a.amocrm.ru"))
+
+	// Buckets are independent per domain.
+	require.Zero(t, limiter.reserve("b.amocrm.ru"))
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter(1000, 2)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), "a.amocrm.ru"))
+	}
+}
+
+func TestRateLimiter_Wait_ContextDone(t *testing.T) {
+	limiter := NewRateLimiter(0.1, 1)
+	require.NoError(t, limiter.Wait(context.Background(), testDomain))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.True(t, errors.Is(limiter.Wait(ctx, testDomain), context.DeadlineExceeded))
+}
+
+type countingLimiter struct {
+	calls int32
+}
+
+func (l *countingLimiter) Wait(_ context.Context, domain string) error {
+	if domain != testDomain {
+		return errors.New("unexpected domain " + domain)
+	}
+	atomic.AddInt32(&l.calls, 1)
+	return nil
+}
+
+func TestAPI_Do_RateLimiter(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	limiter := &countingLimiter{}
+	a.setRateLimiter(limiter)
+
+	for i := 0; i < 3; i++ {
+		_, err := accounts{api: a}.CurrentContext(context.Background(), AccountsConfig{})
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 3, atomic.LoadInt32(&limiter.calls))
+}
+
+func TestAPI_Do_ConcurrentSettings(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			a.setRateLimiter(&countingLimiter{})
+			a.setRetryPolicy(fastRetryPolicy)
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		_, err := accounts{api: a}.CurrentContext(context.Background(), AccountsConfig{})
+		require.NoError(t, err)
+	}
+	wg.Wait()
+}
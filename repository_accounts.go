@@ -23,7 +23,7 @@
 package amocrm
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -43,6 +43,7 @@ const (
 // Accounts describes methods available for Accounts entity.
 type Accounts interface {
 	Current(cfg AccountsConfig) (*Account, error)
+	CurrentContext(ctx context.Context, cfg AccountsConfig) (*Account, error)
 }
 
 // Verify interface compliance.
@@ -62,35 +63,27 @@ func newAccounts(api *api) Accounts {
 }
 
 // Current returns an Accounts entity for current authorized user.
-func (a accounts) Current(cfg AccountsConfig) (dto *Account, err error) {
+func (a accounts) Current(cfg AccountsConfig) (*Account, error) {
+	return a.CurrentContext(context.Background(), cfg)
+}
+
+func (a accounts) CurrentContext(ctx context.Context, cfg AccountsConfig) (dto *Account, err error) {
 	query := url.Values{}
-	for _, relation := range cfg.Relations {
-		switch relation {
-		case WithUUID, WithVersion, WithAmojoID, WithTaskTypes, WithUserGroups, WithAmojoRights, WithDatetimeSettings:
-			query.Add("with", relation)
-		default:
-			return dto, fmt.Errorf("unexpected account relation: %s", relation)
-		}
+	err = addRelations(query, "account", cfg.Relations,
+		WithUUID, WithVersion, WithAmojoID, WithTaskTypes, WithUserGroups, WithAmojoRights, WithDatetimeSettings)
+	if err != nil {
+		return dto, err
 	}
 
-	resp, rErr := a.api.do(accountsEndpoint, http.MethodGet, query, nil, nil)
+	resp, rErr := a.api.do(ctx, accountsEndpoint, http.MethodGet, query, nil, nil)
 	if rErr != nil {
 		return dto, fmt.Errorf("get accounts: %w", rErr)
 	}
-	defer func() {
-		if clErr := resp.Body.Close(); clErr != nil {
-			if err != nil {
-				err = fmt.Errorf("close response body: %v: %v", clErr, err)
-			} else {
-				err = fmt.Errorf("close response body: %w", clErr)
-			}
-		}
-	}()
 
 	dto = &Account{}
-	if dErr := json.NewDecoder(resp.Body).Decode(dto); dErr != nil {
-		return dto, fmt.Errorf("decode json response: %w", dErr)
+	if err = a.api.read(resp, dto); err != nil {
+		return nil, fmt.Errorf("get accounts: %w", err)
 	}
 
-	return dto, err
+	return dto, nil
 }
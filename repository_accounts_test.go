@@ -24,20 +24,24 @@ package amocrm_test
 
 import (
 	"errors"
+	"net/http"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/sintanial/amocrm"
+	"github.com/ros-tel/amocrm"
 )
 
 func TestAccounts_Current(t *testing.T) {
 	noTokenClient := amocrm.New(clientID, clientSecret, redirectURL)
 
-	almostValidClient := amocrm.New(clientID, clientSecret, redirectURL)
-	_ = almostValidClient.SetToken(amocrm.NewToken(accessToken, refreshToken, tokenType, time.Time{}))
-	_ = almostValidClient.SetDomain("example.amocrm.ru")
+	almostValidClient := amocrm.NewTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/accounts" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "name": "example"}`))
+	}))
 
 	relations := []string{
 		amocrm.WithUUID,
@@ -72,7 +76,7 @@ func TestAccounts_Current(t *testing.T) {
 		{
 			client: almostValidClient,
 			error:  nil,
-			wanted: &amocrm.Account{},
+			wanted: &amocrm.Account{ID: 1, Name: "example"},
 			config: amocrm.AccountsConfig{Relations: relations},
 		},
 	}
@@ -88,3 +92,37 @@ func TestAccounts_Current(t *testing.T) {
 		}
 	}
 }
+
+func TestAccounts_Current_APIError(t *testing.T) {
+	client := amocrm.NewTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"title": "Forbidden", "status": 403, "detail": "Account is blocked"}`))
+	}))
+
+	got, err := client.Accounts().Current(amocrm.AccountsConfig{})
+	require.Nil(t, got)
+	require.True(t, errors.Is(err, amocrm.ErrAccountBlocked), err)
+
+	var apiErr *amocrm.APIError
+	require.True(t, errors.As(err, &apiErr), err)
+	require.Equal(t, http.StatusForbidden, apiErr.Status)
+	require.Equal(t, "Account is blocked", apiErr.Detail)
+}
+
+func TestAccounts_Current_RevokedToken(t *testing.T) {
+	client := amocrm.NewTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/accounts":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/oauth2/access_token":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"hint": "Token has been revoked", "status": 400}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	got, err := client.Accounts().Current(amocrm.AccountsConfig{})
+	require.Nil(t, got)
+	require.True(t, errors.Is(err, amocrm.ErrReauthorizationRequired), err)
+}
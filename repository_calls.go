@@ -21,7 +21,7 @@
 package amocrm
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -31,6 +31,7 @@ const (
 )
 
 type (
+	// Error describes a call of the batch that amoCRM failed to add.
 	Error struct {
 		Detail    string `json:"detail"`
 		RequestID string `json:"request_id"`
@@ -59,6 +60,7 @@ type (
 // Calls describes methods available for Calls entity
 type Calls interface {
 	Create(calls []Call) ([]Contact, []Error, error)
+	CreateContext(ctx context.Context, calls []Call) ([]Contact, []Error, error)
 }
 
 // Verify interface compliance.
@@ -74,7 +76,11 @@ func newCalls(api *api) Calls {
 
 // Create returns an Contacts entity for successfully added Calls
 func (a calls) Create(calls []Call) ([]Contact, []Error, error) {
-	resp, rErr := a.api.do(callsEndpoint, http.MethodPost, nil, nil, calls)
+	return a.CreateContext(context.Background(), calls)
+}
+
+func (a calls) CreateContext(ctx context.Context, calls []Call) ([]Contact, []Error, error) {
+	resp, rErr := a.api.do(ctx, callsEndpoint, http.MethodPost, nil, nil, calls)
 	if rErr != nil {
 		return nil, nil, fmt.Errorf("get calls: %w", rErr)
 	}
@@ -85,24 +91,9 @@ func (a calls) Create(calls []Call) ([]Contact, []Error, error) {
 			Contacts []Contact `json:"calls"`
 		} `json:"_embedded"`
 	}
-	if err := a.api.readCall(resp, &res); err != nil {
+	if err := a.api.read(resp, &res); err != nil {
 		return nil, nil, err
 	}
 
 	return res.Embedded.Contacts, res.Errors, nil
 }
-
-func (a *api) readCall(response *http.Response, target interface{}) (err error) {
-	defer func() {
-		if clErr := response.Body.Close(); clErr != nil {
-			if err != nil {
-				err = fmt.Errorf("close response body: %v: %v", clErr, err)
-			} else {
-				err = fmt.Errorf("close response body: %w", clErr)
-			}
-		}
-	}()
-
-	err = json.NewDecoder(response.Body).Decode(target)
-	return
-}
@@ -0,0 +1,275 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	catalogsEndpoint endpoint = "catalogs"
+)
+
+// CatalogType is a kind of a catalog.
+type CatalogType string
+
+const (
+	CatalogRegular  CatalogType = "regular"
+	CatalogInvoices CatalogType = "invoices"
+	CatalogProducts CatalogType = "products"
+)
+
+type Catalog struct {
+	ID              int         `json:"id,omitempty"`
+	Name            string      `json:"name,omitempty"`              //Название списка
+	CreatedBy       int         `json:"created_by,omitempty"`        //ID пользователя, создавшего список
+	UpdatedBy       int         `json:"updated_by,omitempty"`        //ID пользователя, изменившего список последним
+	CreatedAt       int         `json:"created_at,omitempty"`        //Дата создания списка, передается в Unix Timestamp
+	UpdatedAt       int         `json:"updated_at,omitempty"`        //Дата изменения списка, передается в Unix Timestamp
+	Sort            int         `json:"sort,omitempty"`              //Сортировка списка
+	Type            CatalogType `json:"type,omitempty"`              //Тип списка (regular, invoices, products)
+	CanAddElements  bool        `json:"can_add_elements,omitempty"`  //Можно ли добавлять элементы списка из интерфейса
+	CanShowInCards  bool        `json:"can_show_in_cards,omitempty"` //Должна ли добавляться вкладка со списком в карточку сделки/покупателя
+	CanLinkMultiple bool        `json:"can_link_multiple,omitempty"` //Если это список и он привязывается к сделке/покупателю, то можно ли одновременно привязать к сущности несколько элементов
+	CanBeDeleted    bool        `json:"can_be_deleted,omitempty"`    //Может ли список быть удален через интерфейс
+	SDKWidgetCode   string      `json:"sdk_widget_code,omitempty"`   //Код виджета, который управляет списком
+	AccountID       int         `json:"account_id,omitempty"`        //ID аккаунта, в котором находится список
+	RequestID       string      `json:"request_id,omitempty"`        //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+type CatalogElement struct {
+	ID                 int                `json:"id,omitempty"`
+	CatalogID          int                `json:"catalog_id,omitempty"`           //ID списка
+	Name               string             `json:"name,omitempty"`                 //Название элемента
+	CreatedBy          int                `json:"created_by,omitempty"`           //ID пользователя, создавшего элемент
+	UpdatedBy          int                `json:"updated_by,omitempty"`           //ID пользователя, изменившего элемент последним
+	CreatedAt          int                `json:"created_at,omitempty"`           //Дата создания элемента, передается в Unix Timestamp
+	UpdatedAt          int                `json:"updated_at,omitempty"`           //Дата изменения элемента, передается в Unix Timestamp
+	IsDeleted          bool               `json:"is_deleted,omitempty"`           //Удален ли элемент
+	CustomFieldsValues CustomFieldsValues `json:"custom_fields_values,omitempty"` //Значения дополнительных полей элемента
+	AccountID          int                `json:"account_id,omitempty"`           //ID аккаунта, в котором находится элемент
+	RequestID          string             `json:"request_id,omitempty"`           //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+// CatalogsFilter describes parameters of Catalogs list request.
+type CatalogsFilter struct {
+	Page  int
+	Limit int // 250 at most
+}
+
+func (f CatalogsFilter) values() url.Values {
+	q := url.Values{}
+	addPage(q, f.Page, f.Limit)
+
+	return q
+}
+
+// Catalogs describes methods available for catalogs (lists).
+type Catalogs interface {
+	List(ctx context.Context, filter CatalogsFilter) ([]Catalog, error)
+	Iterate(filter CatalogsFilter) *Iterator[Catalog]
+	GetByID(ctx context.Context, id int) (*Catalog, error)
+	ByType(ctx context.Context, catalogType CatalogType) (*Catalog, error)
+	Create(ctx context.Context, catalogs []Catalog) ([]Catalog, error)
+	Update(ctx context.Context, catalogs []Catalog) ([]Catalog, error)
+}
+
+// Verify interface compliance.
+var _ Catalogs = catalogs{}
+
+type catalogs struct {
+	api *api
+}
+
+func newCatalogs(api *api) Catalogs {
+	return catalogs{api: api}
+}
+
+func (a catalogs) List(ctx context.Context, filter CatalogsFilter) ([]Catalog, error) {
+	items, _, err := listPage[Catalog](ctx, a.api, catalogsEndpoint, filter.values(), "catalogs")
+	return items, err
+}
+
+// Iterate walks all catalogs starting from filter.Page.
+func (a catalogs) Iterate(filter CatalogsFilter) *Iterator[Catalog] {
+	return iterate[Catalog](a.api, catalogsEndpoint, "catalogs", filter.Page, filter.values(), nil)
+}
+
+func (a catalogs) GetByID(ctx context.Context, id int) (*Catalog, error) {
+	catalog := &Catalog{}
+	if err := a.api.get(ctx, catalogsEndpoint.id(id), nil, catalog); err != nil {
+		return nil, fmt.Errorf("get catalog %d: %w", id, err)
+	}
+
+	return catalog, nil
+}
+
+// ByType returns the first catalog of the given type, e.g. the products
+// catalog, which exists at most once per account.
+func (a catalogs) ByType(ctx context.Context, catalogType CatalogType) (*Catalog, error) {
+	it := a.Iterate(CatalogsFilter{})
+	for it.Next(ctx) {
+		if catalog := it.Value(); catalog.Type == catalogType {
+			return &catalog, nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("catalog of type %s: %w", catalogType, ErrNotFound)
+}
+
+func (a catalogs) Create(ctx context.Context, catalogs []Catalog) ([]Catalog, error) {
+	return a.save(ctx, http.MethodPost, catalogs)
+}
+
+func (a catalogs) Update(ctx context.Context, catalogs []Catalog) ([]Catalog, error) {
+	return a.save(ctx, http.MethodPatch, catalogs)
+}
+
+func (a catalogs) save(ctx context.Context, method string, catalogs []Catalog) ([]Catalog, error) {
+	resp, rErr := a.api.do(ctx, catalogsEndpoint, method, nil, nil, catalogs)
+	if rErr != nil {
+		return nil, fmt.Errorf("save catalogs: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Catalogs []Catalog `json:"catalogs"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Catalogs, nil
+}
+
+// CatalogElementsFilter describes parameters of CatalogElements list request.
+type CatalogElementsFilter struct {
+	Query string
+	IDs   []int
+	Page  int
+	Limit int // 250 at most
+}
+
+func (f CatalogElementsFilter) values() url.Values {
+	q := url.Values{}
+	if f.Query != "" {
+		q.Set("query", f.Query)
+	}
+	addInts(q, "filter[id]", f.IDs)
+	addPage(q, f.Page, f.Limit)
+
+	return q
+}
+
+// CatalogElements describes methods available for elements of a catalog.
+type CatalogElements interface {
+	List(ctx context.Context, filter CatalogElementsFilter) ([]CatalogElement, error)
+	Iterate(filter CatalogElementsFilter) *Iterator[CatalogElement]
+	GetByID(ctx context.Context, id int) (*CatalogElement, error)
+	Create(ctx context.Context, elements []CatalogElement) ([]CatalogElement, error)
+	Update(ctx context.Context, elements []CatalogElement) ([]CatalogElement, error)
+	LinkToLead(ctx context.Context, leadID, elementID int, quantity float64, priceID int) error
+}
+
+// Verify interface compliance.
+var _ CatalogElements = catalogElements{}
+
+type catalogElements struct {
+	api       *api
+	catalogID int
+}
+
+func newCatalogElements(api *api, catalogID int) CatalogElements {
+	return catalogElements{api: api, catalogID: catalogID}
+}
+
+func (a catalogElements) endpoint() endpoint {
+	return endpoint(CatalogEntity(a.catalogID)) + "/elements"
+}
+
+func (a catalogElements) List(ctx context.Context, filter CatalogElementsFilter) ([]CatalogElement, error) {
+	items, _, err := listPage[CatalogElement](ctx, a.api, a.endpoint(), filter.values(), "elements")
+	return items, err
+}
+
+// Iterate walks all catalog elements matching the filter starting from filter.Page.
+func (a catalogElements) Iterate(filter CatalogElementsFilter) *Iterator[CatalogElement] {
+	return iterate[CatalogElement](a.api, a.endpoint(), "elements", filter.Page, filter.values(), nil)
+}
+
+func (a catalogElements) GetByID(ctx context.Context, id int) (*CatalogElement, error) {
+	element := &CatalogElement{}
+	if err := a.api.get(ctx, a.endpoint().id(id), nil, element); err != nil {
+		return nil, fmt.Errorf("get catalog element %d: %w", id, err)
+	}
+
+	return element, nil
+}
+
+func (a catalogElements) Create(ctx context.Context, elements []CatalogElement) ([]CatalogElement, error) {
+	return a.save(ctx, http.MethodPost, elements)
+}
+
+func (a catalogElements) Update(ctx context.Context, elements []CatalogElement) ([]CatalogElement, error) {
+	return a.save(ctx, http.MethodPatch, elements)
+}
+
+func (a catalogElements) save(ctx context.Context, method string, elements []CatalogElement) ([]CatalogElement, error) {
+	resp, rErr := a.api.do(ctx, a.endpoint(), method, nil, nil, elements)
+	if rErr != nil {
+		return nil, fmt.Errorf("save catalog elements: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Elements []CatalogElement `json:"elements"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Elements, nil
+}
+
+// LinkToLead attaches the element to the lead. PriceID is an ID of the price
+// custom field of the catalog and may be zero to use the default one.
+func (a catalogElements) LinkToLead(ctx context.Context, leadID, elementID int, quantity float64, priceID int) error {
+	_, err := newLinks(a.api, EntityLeads).Link(ctx, []Link{{
+		EntityID:     leadID,
+		ToEntityID:   elementID,
+		ToEntityType: EntityCatalogElements,
+		Metadata: &LinkMetadata{
+			CatalogID: a.catalogID,
+			Quantity:  quantity,
+			PriceID:   priceID,
+		},
+	}})
+
+	return err
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs_ByType(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/catalogs", r.URL.Path)
+		_, _ = w.Write([]byte(`{"_embedded": {"catalogs": [
+			{"id": 1, "name": "Счета", "type": "invoices"},
+			{"id": 2, "name": "Товары", "type": "products", "can_link_multiple": true}
+		]}}`))
+	}))
+
+	catalog, err := catalogs{api: a}.ByType(context.Background(), CatalogProducts)
+	require.NoError(t, err)
+	require.Equal(t, &Catalog{ID: 2, Name: "Товары", Type: CatalogProducts, CanLinkMultiple: true}, catalog)
+
+	_, err = catalogs{api: a}.ByType(context.Background(), CatalogRegular)
+	require.True(t, errors.Is(err, ErrNotFound), err)
+}
+
+func TestCatalogElements_List(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/catalogs/2/elements", r.URL.Path)
+		assert.Equal(t, "SKU-1", r.URL.Query().Get("query"))
+		_, _ = w.Write([]byte(`{"_embedded": {"elements": [
+			{"id": 5, "catalog_id": 2, "name": "Товар", "custom_fields_values": [
+				{"field_id": 11, "field_code": "SKU", "values": [{"value": "SKU-1"}]},
+				{"field_id": 12, "field_code": "PRICE", "values": [{"value": "990"}]}
+			]}
+		]}}`))
+	}))
+
+	items, err := catalogElements{api: a, catalogID: 2}.List(context.Background(), CatalogElementsFilter{Query: "SKU-1"})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	price, err := items[0].CustomFieldsValues.ByCode("PRICE").AsFloat()
+	require.NoError(t, err)
+	require.Equal(t, 990.0, price)
+}
+
+func TestCatalogElements_LinkToLead(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads/link", r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"entity_id": 7, "to_entity_id": 5, "to_entity_type": "catalog_elements",
+			"metadata": {"catalog_id": 2, "quantity": 3, "price_id": 12}}]`, string(body))
+
+		_, _ = w.Write([]byte(`{"_embedded": {"links": []}}`))
+	}))
+
+	require.NoError(t, catalogElements{api: a, catalogID: 2}.LinkToLead(context.Background(), 7, 5, 3, 12))
+}
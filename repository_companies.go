@@ -0,0 +1,181 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+var companyRelations = []string{
+	WithLeads,
+	WithContacts,
+	WithCustomers,
+	WithCatalogElements,
+}
+
+type CompanyEmbedded struct {
+	Tags            []Tag                    `json:"tags,omitempty"`
+	Contacts        []EmbeddedContact        `json:"contacts,omitempty"`
+	Leads           []EmbeddedLead           `json:"leads,omitempty"`
+	Customers       []EmbeddedCustomer       `json:"customers,omitempty"`
+	CatalogElements []EmbeddedCatalogElement `json:"catalog_elements,omitempty"`
+}
+
+type Company struct {
+	Id                 int                `json:"id,omitempty"`
+	Name               string             `json:"name,omitempty"`                 //Название компании
+	ResponsibleUserId  int                `json:"responsible_user_id,omitempty"`  //ID пользователя, ответственного за компанию
+	GroupId            int                `json:"group_id,omitempty"`             //ID группы, в которой состоит ответственный пользователь за компанию
+	CreatedBy          int                `json:"created_by,omitempty"`           //ID пользователя, создавший компанию
+	UpdatedBy          int                `json:"updated_by,omitempty"`           //ID пользователя, изменивший компанию
+	CreatedAt          int                `json:"created_at,omitempty"`           //Дата создания компании, передается в Unix Timestamp
+	UpdatedAt          int                `json:"updated_at,omitempty"`           //Дата изменения компании, передается в Unix Timestamp
+	ClosestTaskAt      int                `json:"closest_task_at,omitempty"`      //Дата ближайшей задачи к выполнению, передается в Unix Timestamp
+	IsDeleted          bool               `json:"is_deleted,omitempty"`           //Удалена ли компания
+	AccountId          int                `json:"account_id,omitempty"`           //ID аккаунта, в котором находится компания
+	CustomFieldsValues CustomFieldsValues `json:"custom_fields_values,omitempty"` //Массив, содержащий информацию по значениям дополнительных полей, заданных для данной компании
+	Embedded           *CompanyEmbedded   `json:"_embedded,omitempty"`            //Данные вложенных сущностей, при создании и редактировании можно передать только теги
+	RequestID          string             `json:"request_id,omitempty"`           //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+// CompaniesFilter describes parameters of Companies list request.
+type CompaniesFilter struct {
+	Relations          []string
+	Query              string
+	IDs                []int
+	Names              []string
+	CreatedAt          *Range
+	UpdatedAt          *Range
+	ResponsibleUserIDs []int
+	Order              *Order // by updated_at or id
+	Page               int
+	Limit              int // 250 at most
+}
+
+func (f CompaniesFilter) values() (url.Values, error) {
+	q := url.Values{}
+	if err := addRelations(q, "company", f.Relations, companyRelations...); err != nil {
+		return nil, err
+	}
+	if f.Query != "" {
+		q.Set("query", f.Query)
+	}
+	addInts(q, "filter[id]", f.IDs)
+	addStrings(q, "filter[name]", f.Names)
+	addRange(q, "filter[created_at]", f.CreatedAt)
+	addRange(q, "filter[updated_at]", f.UpdatedAt)
+	addInts(q, "filter[responsible_user_id]", f.ResponsibleUserIDs)
+	if err := addOrder(q, f.Order, "updated_at", "id"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+// Companies describes methods available for Companies entity.
+type Companies interface {
+	List(ctx context.Context, filter CompaniesFilter) ([]Company, error)
+	Iterate(filter CompaniesFilter) *Iterator[Company]
+	GetByID(ctx context.Context, id int, with ...string) (*Company, error)
+	Create(companies []Company) ([]Company, error)
+	CreateContext(ctx context.Context, companies []Company) ([]Company, error)
+	Update(companies []Company) ([]Company, error)
+	UpdateContext(ctx context.Context, companies []Company) ([]Company, error)
+}
+
+// Verify interface compliance.
+var _ Companies = companies{}
+
+type companies struct {
+	api *api
+}
+
+func newCompanies(api *api) Companies {
+	return companies{api: api}
+}
+
+func (a companies) List(ctx context.Context, filter CompaniesFilter) ([]Company, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Company](ctx, a.api, companiesEndpoint, query, "companies")
+	return items, err
+}
+
+// Iterate walks all companies matching the filter starting from filter.Page.
+func (a companies) Iterate(filter CompaniesFilter) *Iterator[Company] {
+	query, err := filter.values()
+	return iterate[Company](a.api, companiesEndpoint, "companies", filter.Page, query, err)
+}
+
+func (a companies) GetByID(ctx context.Context, id int, with ...string) (*Company, error) {
+	query := url.Values{}
+	if err := addRelations(query, "company", with, companyRelations...); err != nil {
+		return nil, err
+	}
+
+	company := &Company{}
+	if err := a.api.get(ctx, companiesEndpoint.id(id), query, company); err != nil {
+		return nil, fmt.Errorf("get company %d: %w", id, err)
+	}
+
+	return company, nil
+}
+
+func (a companies) Create(companies []Company) ([]Company, error) {
+	return a.CreateContext(context.Background(), companies)
+}
+
+func (a companies) CreateContext(ctx context.Context, companies []Company) ([]Company, error) {
+	return a.save(ctx, http.MethodPost, companies)
+}
+
+func (a companies) Update(companies []Company) ([]Company, error) {
+	return a.UpdateContext(context.Background(), companies)
+}
+
+func (a companies) UpdateContext(ctx context.Context, companies []Company) ([]Company, error) {
+	return a.save(ctx, http.MethodPatch, companies)
+}
+
+func (a companies) save(ctx context.Context, method string, companies []Company) ([]Company, error) {
+	resp, rErr := a.api.do(ctx, companiesEndpoint, method, nil, nil, companies)
+	if rErr != nil {
+		return nil, fmt.Errorf("save companies: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Companies []Company `json:"companies"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Companies, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanies_List(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/companies", r.URL.Path)
+		assert.Equal(t, []string{WithContacts}, r.URL.Query()["with"])
+		assert.Equal(t, "ООО", r.URL.Query().Get("query"))
+		_, _ = w.Write([]byte(`{"_embedded": {"companies": [{"id": 1, "name": "ООО Ромашка", "_embedded": {"contacts": [{"id": 2}]}}]}}`))
+	}))
+
+	got, err := companies{api: a}.List(context.Background(), CompaniesFilter{
+		Relations: []string{WithContacts},
+		Query:     "ООО",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Company{{
+		Id:       1,
+		Name:     "ООО Ромашка",
+		Embedded: &CompanyEmbedded{Contacts: []EmbeddedContact{{Id: 2}}},
+	}}, got)
+}
+
+func TestCompanies_Create(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `[{"name":"ООО Ромашка","responsible_user_id":5}]`, string(body))
+		_, _ = w.Write([]byte(`{"_embedded": {"companies": [{"id": 1, "request_id": "0"}]}}`))
+	}))
+
+	got, err := companies{api: a}.CreateContext(context.Background(), []Company{{Name: "ООО Ромашка", ResponsibleUserId: 5}})
+	require.NoError(t, err)
+	require.Equal(t, []Company{{Id: 1, RequestID: "0"}}, got)
+}
+
+func TestCompanies_Create_RetryWithRequestID(t *testing.T) {
+	var hits int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `[{"name":"ООО Ромашка","request_id":"row-1"}]`, string(body))
+
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"_embedded": {"companies": [{"id": 1, "request_id": "row-1"}]}}`))
+	}))
+	a.setRetryPolicy(fastRetryPolicy)
+
+	got, err := companies{api: a}.CreateContext(context.Background(), []Company{{Name: "ООО Ромашка", RequestID: "row-1"}})
+	require.NoError(t, err)
+	require.Equal(t, []Company{{Id: 1, RequestID: "row-1"}}, got)
+	require.EqualValues(t, 2, atomic.LoadInt32(&hits))
+}
@@ -23,34 +23,99 @@
 package amocrm
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 )
 
+// Relations available for Contacts.
+const (
+	WithLeads     = "leads"
+	WithCustomers = "customers"
+)
+
+var contactRelations = []string{
+	WithLeads,
+	WithCustomers,
+	WithCatalogElements,
+}
+
 type ContactsEmbedded struct {
-	Tags []FieldValues `json:"tags,omitempty"`
+	Tags            []Tag                    `json:"tags,omitempty"`
+	Companies       []EmbeddedCompany        `json:"companies,omitempty"`
+	Leads           []EmbeddedLead           `json:"leads,omitempty"`
+	Customers       []EmbeddedCustomer       `json:"customers,omitempty"`
+	CatalogElements []EmbeddedCatalogElement `json:"catalog_elements,omitempty"`
 }
 
 type Contact struct {
-	Id                 int               `json:"id,omitempty"`
-	Name               string            `json:"name,omitempty"`                 //Название контакта
-	FirstName          string            `json:"first_name,omitempty"`           //Имя контакта
-	LastName           string            `json:"last_name,omitempty"`            //Фамилия контакта
-	ResponsibleUserId  int               `json:"responsible_user_id,omitempty"`  //ID пользователя, ответственного за контакт
-	CreatedBy          int               `json:"created_by,omitempty"`           //ID пользователя, создавший контакт
-	UpdatedBy          int               `json:"updated_by,omitempty"`           //ID пользователя, изменивший контакт
-	CreatedAt          int               `json:"created_at,omitempty"`           //Дата создания контакта, передается в Unix Timestamp
-	UpdatedAt          int               `json:"updated_at,omitempty"`           //Дата изменения контакта, передается в Unix Timestamp
-	CustomFieldsValues []FieldValues     `json:"custom_fields_values,omitempty"` //Массив, содержащий информацию по дополнительным полям, заданным для данной сделки. Поле не является обязательным. Примеры заполнения полей
-	Embedded           *ContactsEmbedded `json:"_embedded,omitempty"`            //Данные вложенных сущностей, при создании и редактировании можно передать только теги. Поле не является обязательным
+	Id                 int                `json:"id,omitempty"`
+	Name               string             `json:"name,omitempty"`                 //Название контакта
+	FirstName          string             `json:"first_name,omitempty"`           //Имя контакта
+	LastName           string             `json:"last_name,omitempty"`            //Фамилия контакта
+	ResponsibleUserId  int                `json:"responsible_user_id,omitempty"`  //ID пользователя, ответственного за контакт
+	GroupId            int                `json:"group_id,omitempty"`             //ID группы, в которой состоит ответственный пользователь за контакт
+	CreatedBy          int                `json:"created_by,omitempty"`           //ID пользователя, создавший контакт
+	UpdatedBy          int                `json:"updated_by,omitempty"`           //ID пользователя, изменивший контакт
+	CreatedAt          int                `json:"created_at,omitempty"`           //Дата создания контакта, передается в Unix Timestamp
+	UpdatedAt          int                `json:"updated_at,omitempty"`           //Дата изменения контакта, передается в Unix Timestamp
+	ClosestTaskAt      int                `json:"closest_task_at,omitempty"`      //Дата ближайшей задачи к выполнению, передается в Unix Timestamp
+	IsDeleted          bool               `json:"is_deleted,omitempty"`           //Удален ли элемент
+	IsUnsorted         bool               `json:"is_unsorted,omitempty"`          //Является ли контакт неразобранным
+	AccountId          int                `json:"account_id,omitempty"`           //ID аккаунта, в котором находится контакт
+	CustomFieldsValues CustomFieldsValues `json:"custom_fields_values,omitempty"` //Массив, содержащий информацию по дополнительным полям, заданным для данной сделки. Поле не является обязательным. Примеры заполнения полей
+	Embedded           *ContactsEmbedded  `json:"_embedded,omitempty"`            //Данные вложенных сущностей, при создании и редактировании можно передать только теги. Поле не является обязательным
+	RequestID          string             `json:"request_id,omitempty"`           //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+// ContactsFilter describes parameters of Contacts list request.
+type ContactsFilter struct {
+	Relations          []string
+	Query              string
+	IDs                []int
+	Names              []string
+	CreatedAt          *Range
+	UpdatedAt          *Range
+	ResponsibleUserIDs []int
+	Order              *Order // by updated_at or id
+	Page               int
+	Limit              int // 250 at most
+}
+
+func (f ContactsFilter) values() (url.Values, error) {
+	q := url.Values{}
+	if err := addRelations(q, "contact", f.Relations, contactRelations...); err != nil {
+		return nil, err
+	}
+	if f.Query != "" {
+		q.Set("query", f.Query)
+	}
+	addInts(q, "filter[id]", f.IDs)
+	addStrings(q, "filter[name]", f.Names)
+	addRange(q, "filter[created_at]", f.CreatedAt)
+	addRange(q, "filter[updated_at]", f.UpdatedAt)
+	addInts(q, "filter[responsible_user_id]", f.ResponsibleUserIDs)
+	if err := addOrder(q, f.Order, "updated_at", "id"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
 }
 
 // Contacts describes methods available for Contacts entity.
 type Contacts interface {
 	Contacts(values url.Values) ([]Contact, error)
+	ContactsContext(ctx context.Context, values url.Values) ([]Contact, error)
+	List(ctx context.Context, filter ContactsFilter) ([]Contact, error)
+	Iterate(filter ContactsFilter) *Iterator[Contact]
+	GetByID(ctx context.Context, id int, with ...string) (*Contact, error)
 	Create(contacts []Contact) ([]Contact, error)
+	CreateContext(ctx context.Context, contacts []Contact) ([]Contact, error)
+	Update(contacts []Contact) ([]Contact, error)
+	UpdateContext(ctx context.Context, contacts []Contact) ([]Contact, error)
+	UpdateOne(ctx context.Context, contact Contact) (*Contact, error)
 }
 
 // Verify interface compliance.
@@ -64,23 +129,53 @@ func newContacts(api *api) Contacts {
 	return contacts{api: api}
 }
 
+// Contacts returns a single page of contacts matching raw query values.
+// Use List or Iterate to build the query with ContactsFilter.
 func (a contacts) Contacts(values url.Values) ([]Contact, error) {
-	r, err := a.api.do(contactsEndpoint, http.MethodGet, values, nil, nil)
+	return a.ContactsContext(context.Background(), values)
+}
+
+func (a contacts) ContactsContext(ctx context.Context, values url.Values) ([]Contact, error) {
+	items, _, err := listPage[Contact](ctx, a.api, contactsEndpoint, values, "contacts")
+	return items, err
+}
+
+func (a contacts) List(ctx context.Context, filter ContactsFilter) ([]Contact, error) {
+	query, err := filter.values()
 	if err != nil {
 		return nil, err
 	}
 
-	var res []Contact
-	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+	return a.ContactsContext(ctx, query)
+}
+
+// Iterate walks all contacts matching the filter starting from filter.Page.
+func (a contacts) Iterate(filter ContactsFilter) *Iterator[Contact] {
+	query, err := filter.values()
+	return iterate[Contact](a.api, contactsEndpoint, "contacts", filter.Page, query, err)
+}
+
+func (a contacts) GetByID(ctx context.Context, id int, with ...string) (*Contact, error) {
+	query := url.Values{}
+	if err := addRelations(query, "contact", with, contactRelations...); err != nil {
 		return nil, err
 	}
 
-	return res, err
+	contact := &Contact{}
+	if err := a.api.get(ctx, contactsEndpoint.id(id), query, contact); err != nil {
+		return nil, fmt.Errorf("get contact %d: %w", id, err)
+	}
+
+	return contact, nil
 }
 
 // Current returns an Contacts entity for current authorized user.
 func (a contacts) Create(contacts []Contact) ([]Contact, error) {
-	resp, rErr := a.api.do(contactsEndpoint, http.MethodPost, nil, nil, contacts)
+	return a.CreateContext(context.Background(), contacts)
+}
+
+func (a contacts) CreateContext(ctx context.Context, contacts []Contact) ([]Contact, error) {
+	resp, rErr := a.api.do(ctx, contactsEndpoint, http.MethodPost, nil, nil, contacts)
 	if rErr != nil {
 		return nil, fmt.Errorf("get contacts: %w", rErr)
 	}
@@ -96,3 +191,40 @@ func (a contacts) Create(contacts []Contact) ([]Contact, error) {
 
 	return res.Embedded.Contacts, nil
 }
+
+func (a contacts) Update(contacts []Contact) ([]Contact, error) {
+	return a.UpdateContext(context.Background(), contacts)
+}
+
+func (a contacts) UpdateContext(ctx context.Context, contacts []Contact) ([]Contact, error) {
+	resp, rErr := a.api.do(ctx, contactsEndpoint, http.MethodPatch, nil, nil, contacts)
+	if rErr != nil {
+		return nil, fmt.Errorf("update contacts: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Contacts []Contact `json:"contacts"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Contacts, nil
+}
+
+// UpdateOne updates a single contact identified by contact.Id.
+func (a contacts) UpdateOne(ctx context.Context, contact Contact) (*Contact, error) {
+	resp, rErr := a.api.do(ctx, contactsEndpoint.id(contact.Id), http.MethodPatch, nil, nil, contact)
+	if rErr != nil {
+		return nil, fmt.Errorf("update contact: %w", rErr)
+	}
+
+	res := &Contact{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContacts_List(t *testing.T) {
+	var query url.Values
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/contacts", r.URL.Path)
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{
+			"_page": 1,
+			"_embedded": {"contacts": [{
+				"id": 3,
+				"name": "Иван",
+				"_embedded": {"leads": [{"id": 7}], "companies": []}
+			}]}
+		}`))
+	}))
+
+	got, err := contacts{api: a}.List(context.Background(), ContactsFilter{
+		Relations: []string{WithLeads},
+		Names:     []string{"Иван"},
+		UpdatedAt: &Range{From: 1, To: 2},
+		Order:     &Order{Field: "id", Direction: OrderAsc},
+	})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"with":                     {WithLeads},
+		"filter[name][]":           {"Иван"},
+		"filter[updated_at][from]": {"1"},
+		"filter[updated_at][to]":   {"2"},
+		"order[id]":                {"asc"},
+	}, query)
+	require.Len(t, got, 1)
+	require.Equal(t, "Иван", got[0].Name)
+	require.Equal(t, []EmbeddedLead{{Id: 7}}, got[0].Embedded.Leads)
+
+	// Raw query values are decoded from the same envelope.
+	got, err = contacts{api: a}.Contacts(url.Values{"query": {"Иван"}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
+
+func TestContacts_GetByID(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/contacts/3", r.URL.Path)
+		assert.Equal(t, []string{WithLeads + "," + WithCustomers}, r.URL.Query()["with"])
+		_, _ = w.Write([]byte(`{"id": 3, "_embedded": {"customers": [{"id": 9}]}}`))
+	}))
+
+	got, err := contacts{api: a}.GetByID(context.Background(), 3, WithLeads, WithCustomers)
+	require.NoError(t, err)
+	require.Equal(t, []EmbeddedCustomer{{Id: 9}}, got.Embedded.Customers)
+
+	_, err = contacts{api: a}.GetByID(context.Background(), 3, WithLossReason)
+	require.EqualError(t, err, "unexpected contact relation: loss_reason")
+}
+
+func TestContacts_Update(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/api/v4/contacts":
+			assert.JSONEq(t, `[{"id":3,"name":"Пётр"}]`, string(body))
+			_, _ = w.Write([]byte(`{"_embedded": {"contacts": [{"id": 3, "updated_at": 100}]}}`))
+		case "/api/v4/contacts/3":
+			assert.JSONEq(t, `{"id":3,"first_name":"Пётр"}`, string(body))
+			_, _ = w.Write([]byte(`{"id": 3, "updated_at": 200}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+
+	batch, err := contacts{api: a}.UpdateContext(context.Background(), []Contact{{Id: 3, Name: "Пётр"}})
+	require.NoError(t, err)
+	require.Equal(t, []Contact{{Id: 3, UpdatedAt: 100}}, batch)
+
+	one, err := contacts{api: a}.UpdateOne(context.Background(), Contact{Id: 3, FirstName: "Пётр"})
+	require.NoError(t, err)
+	require.Equal(t, &Contact{Id: 3, UpdatedAt: 200}, one)
+}
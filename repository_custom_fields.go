@@ -0,0 +1,242 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type CustomFieldEnum struct {
+	ID    int    `json:"id,omitempty"`
+	Value string `json:"value"`          //Значение варианта
+	Sort  int    `json:"sort,omitempty"` //Сортировка варианта
+}
+
+type CustomFieldNested struct {
+	ID       int    `json:"id,omitempty"`
+	ParentID int    `json:"parent_id,omitempty"` //ID родительского вложенного значения
+	Value    string `json:"value"`               //Значение вложенного значения
+	Sort     int    `json:"sort,omitempty"`      //Сортировка вложенного значения
+}
+
+type CustomFieldStatus struct {
+	PipelineID int `json:"pipeline_id"`
+	StatusID   int `json:"status_id"`
+}
+
+// CustomField is a definition of an account custom field.
+type CustomField struct {
+	ID               int                 `json:"id,omitempty"`
+	Name             string              `json:"name,omitempty"`              //Название поля
+	Type             FieldType           `json:"type,omitempty"`              //Тип поля
+	Code             string              `json:"code,omitempty"`              //Код поля, по-которому можно обновлять значение в сущности, без передачи ID поля
+	Sort             int                 `json:"sort,omitempty"`              //Сортировка поля
+	GroupID          string              `json:"group_id,omitempty"`          //ID группы полей, в которой состоит данное поле
+	EntityType       string              `json:"entity_type,omitempty"`       //Тип сущности (leads, contacts, companies, segments, customers, catalogs)
+	AccountID        int                 `json:"account_id,omitempty"`        //ID аккаунта, в котором находится поле
+	CatalogID        int                 `json:"catalog_id,omitempty"`        //ID списка или каталога. Поле существует только для списков (каталогов)
+	IsAPIOnly        bool                `json:"is_api_only,omitempty"`       //Доступно ли поле для редактирования только через API
+	IsPredefined     bool                `json:"is_predefined,omitempty"`     //Является ли поле предустановленным
+	IsDeletable      bool                `json:"is_deletable,omitempty"`      //Доступно ли поле для удаления
+	IsVisible        bool                `json:"is_visible,omitempty"`        //Отображается ли поле в интерфейсе списка
+	IsRequired       bool                `json:"is_required,omitempty"`       //Обязательно ли поле для заполнения при создании элемента списка
+	Remind           string              `json:"remind,omitempty"`            //Когда напоминать о дне рождения (never, day, week, month)
+	Currency         string              `json:"currency,omitempty"`          //Код валюты поля типа monetary
+	TrackingCallback string              `json:"tracking_callback,omitempty"` //Callback js-функция, которая будет выполнена на странице с CRM Plugin
+	Settings         json.RawMessage     `json:"settings,omitempty"`          //Настройки поля
+	Enums            []CustomFieldEnum   `json:"enums,omitempty"`             //Доступные значения для поля
+	Nested           []CustomFieldNested `json:"nested,omitempty"`            //Вложенные значения. Данные доступны только для поля category
+	RequiredStatuses []CustomFieldStatus `json:"required_statuses,omitempty"` //Статусы, в которых поле обязательно для заполнения
+	HiddenStatuses   []CustomFieldStatus `json:"hidden_statuses,omitempty"`   //Статусы, в которых поле скрыто
+}
+
+// CustomFieldGroup is a group of custom fields shown on a separate tab.
+type CustomFieldGroup struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`          //Название группы
+	Sort         int    `json:"sort,omitempty"`          //Сортировка группы
+	EntityType   string `json:"entity_type,omitempty"`   //Тип сущности группы
+	IsPredefined bool   `json:"is_predefined,omitempty"` //Является ли группа предустановленной
+	Type         string `json:"type,omitempty"`          //Тип группы
+}
+
+// CustomFieldsFilter describes parameters of CustomFields list request.
+type CustomFieldsFilter struct {
+	Types []FieldType
+	Page  int
+	Limit int // 50 at most
+}
+
+func (f CustomFieldsFilter) values() url.Values {
+	q := url.Values{}
+	for _, t := range f.Types {
+		q.Add("filter[type][]", string(t))
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q
+}
+
+// CustomFields describes methods available for custom fields of an entity.
+type CustomFields interface {
+	List(ctx context.Context, filter CustomFieldsFilter) ([]CustomField, error)
+	Iterate(filter CustomFieldsFilter) *Iterator[CustomField]
+	GetByID(ctx context.Context, id int) (*CustomField, error)
+	Create(ctx context.Context, fields []CustomField) ([]CustomField, error)
+	Update(ctx context.Context, fields []CustomField) ([]CustomField, error)
+	Delete(ctx context.Context, id int) error
+	ListGroups(ctx context.Context) ([]CustomFieldGroup, error)
+	IterateGroups() *Iterator[CustomFieldGroup]
+	GetGroup(ctx context.Context, id string) (*CustomFieldGroup, error)
+	CreateGroups(ctx context.Context, groups []CustomFieldGroup) ([]CustomFieldGroup, error)
+	UpdateGroup(ctx context.Context, group CustomFieldGroup) (*CustomFieldGroup, error)
+	DeleteGroup(ctx context.Context, id string) error
+}
+
+// Verify interface compliance.
+var _ CustomFields = customFields{}
+
+type customFields struct {
+	api    *api
+	entity EntityType
+}
+
+func newCustomFields(api *api, entity EntityType) CustomFields {
+	return customFields{api: api, entity: entity}
+}
+
+func (a customFields) endpoint() endpoint {
+	return endpoint(string(a.entity) + "/custom_fields")
+}
+
+func (a customFields) groupsEndpoint() endpoint {
+	return a.endpoint() + "/groups"
+}
+
+func (a customFields) List(ctx context.Context, filter CustomFieldsFilter) ([]CustomField, error) {
+	items, _, err := listPage[CustomField](ctx, a.api, a.endpoint(), filter.values(), "custom_fields")
+	return items, err
+}
+
+// Iterate walks all custom fields matching the filter starting from filter.Page.
+func (a customFields) Iterate(filter CustomFieldsFilter) *Iterator[CustomField] {
+	return iterate[CustomField](a.api, a.endpoint(), "custom_fields", filter.Page, filter.values(), nil)
+}
+
+func (a customFields) GetByID(ctx context.Context, id int) (*CustomField, error) {
+	field := &CustomField{}
+	if err := a.api.get(ctx, a.endpoint().id(id), nil, field); err != nil {
+		return nil, fmt.Errorf("get custom field %d: %w", id, err)
+	}
+
+	return field, nil
+}
+
+func (a customFields) Create(ctx context.Context, fields []CustomField) ([]CustomField, error) {
+	return a.save(ctx, http.MethodPost, fields)
+}
+
+func (a customFields) Update(ctx context.Context, fields []CustomField) ([]CustomField, error) {
+	return a.save(ctx, http.MethodPatch, fields)
+}
+
+func (a customFields) save(ctx context.Context, method string, fields []CustomField) ([]CustomField, error) {
+	resp, rErr := a.api.do(ctx, a.endpoint(), method, nil, nil, fields)
+	if rErr != nil {
+		return nil, fmt.Errorf("save custom fields: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			CustomFields []CustomField `json:"custom_fields"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.CustomFields, nil
+}
+
+func (a customFields) Delete(ctx context.Context, id int) error {
+	return a.api.delete(ctx, a.endpoint().id(id))
+}
+
+// ListGroups returns the first page of groups, use IterateGroups to get
+// all of them.
+func (a customFields) ListGroups(ctx context.Context) ([]CustomFieldGroup, error) {
+	items, _, err := listPage[CustomFieldGroup](ctx, a.api, a.groupsEndpoint(), nil, "custom_field_groups")
+	return items, err
+}
+
+// IterateGroups walks all groups of custom fields of the entity.
+func (a customFields) IterateGroups() *Iterator[CustomFieldGroup] {
+	return iterate[CustomFieldGroup](a.api, a.groupsEndpoint(), "custom_field_groups", 1, nil, nil)
+}
+
+func (a customFields) GetGroup(ctx context.Context, id string) (*CustomFieldGroup, error) {
+	group := &CustomFieldGroup{}
+	if err := a.api.get(ctx, a.groupsEndpoint()+endpoint("/"+id), nil, group); err != nil {
+		return nil, fmt.Errorf("get custom field group %s: %w", id, err)
+	}
+
+	return group, nil
+}
+
+func (a customFields) CreateGroups(ctx context.Context, groups []CustomFieldGroup) ([]CustomFieldGroup, error) {
+	resp, rErr := a.api.do(ctx, a.groupsEndpoint(), http.MethodPost, nil, nil, groups)
+	if rErr != nil {
+		return nil, fmt.Errorf("create custom field groups: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Groups []CustomFieldGroup `json:"custom_field_groups"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Groups, nil
+}
+
+func (a customFields) UpdateGroup(ctx context.Context, group CustomFieldGroup) (*CustomFieldGroup, error) {
+	resp, rErr := a.api.do(ctx, a.groupsEndpoint()+endpoint("/"+group.ID), http.MethodPatch, nil, nil, group)
+	if rErr != nil {
+		return nil, fmt.Errorf("update custom field group: %w", rErr)
+	}
+
+	res := &CustomFieldGroup{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a customFields) DeleteGroup(ctx context.Context, id string) error {
+	return a.api.delete(ctx, a.groupsEndpoint()+endpoint("/"+id))
+}
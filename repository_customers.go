@@ -0,0 +1,625 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	customersEndpoint            endpoint = "customers"
+	customerTransactionsEndpoint endpoint = "customers/transactions"
+	customerStatusesEndpoint     endpoint = "customers/statuses"
+	customerSegmentsEndpoint     endpoint = "customers/segments"
+)
+
+// Customers modes of an account, see Account.CustomersMode.
+const (
+	CustomersModeUnavailable = "unavailable"
+	CustomersModeDisabled    = "disabled"
+	CustomersModeSegments    = "segments"
+	CustomersModeDynamic     = "dynamic"
+	CustomersModePeriodicity = "periodicity"
+)
+
+// Relations available for Customers.
+const (
+	WithCompanies = "companies"
+	WithSegments  = "segments"
+)
+
+var customerRelations = []string{
+	WithCatalogElements,
+	WithContacts,
+	WithCompanies,
+	WithSegments,
+}
+
+// CustomersEnabled reports whether customers are turned on in the account.
+func (a *Account) CustomersEnabled() bool {
+	switch a.CustomersMode {
+	case CustomersModeSegments, CustomersModeDynamic, CustomersModePeriodicity:
+		return true
+	default:
+		return false
+	}
+}
+
+type EmbeddedSegment struct {
+	ID int `json:"id"`
+}
+
+type CustomerEmbedded struct {
+	Tags            []Tag                    `json:"tags,omitempty"`
+	Segments        []EmbeddedSegment        `json:"segments,omitempty"`
+	Contacts        []EmbeddedContact        `json:"contacts,omitempty"`
+	Companies       []EmbeddedCompany        `json:"companies,omitempty"`
+	CatalogElements []EmbeddedCatalogElement `json:"catalog_elements,omitempty"`
+}
+
+type Customer struct {
+	ID                 int                `json:"id,omitempty"`
+	Name               string             `json:"name,omitempty"`                 //Название покупателя
+	NextPrice          int                `json:"next_price,omitempty"`           //Ожидаемая сумма покупки
+	NextDate           int                `json:"next_date,omitempty"`            //Ожидаемая дата следующей покупки, передается в Unix Timestamp
+	ResponsibleUserID  int                `json:"responsible_user_id,omitempty"`  //ID пользователя, ответственного за покупателя
+	StatusID           int                `json:"status_id,omitempty"`            //ID статуса, в котором находится покупатель
+	Periodicity        int                `json:"periodicity,omitempty"`          //Периодичность покупок в днях, только для режима periodicity
+	CreatedBy          int                `json:"created_by,omitempty"`           //ID пользователя, создавшего покупателя
+	UpdatedBy          int                `json:"updated_by,omitempty"`           //ID пользователя, изменившего покупателя
+	CreatedAt          int                `json:"created_at,omitempty"`           //Дата создания покупателя, передается в Unix Timestamp
+	UpdatedAt          int                `json:"updated_at,omitempty"`           //Дата изменения покупателя, передается в Unix Timestamp
+	ClosestTaskAt      int                `json:"closest_task_at,omitempty"`      //Дата ближайшей задачи к выполнению, передается в Unix Timestamp
+	IsDeleted          bool               `json:"is_deleted,omitempty"`           //Удален ли покупатель
+	LTV                int                `json:"ltv,omitempty"`                  //Сумма покупок
+	PurchasesCount     int                `json:"purchases_count,omitempty"`      //Количество покупок
+	AverageCheck       int                `json:"average_check,omitempty"`        //Средний чек
+	AccountID          int                `json:"account_id,omitempty"`           //ID аккаунта, в котором находится покупатель
+	CustomFieldsValues CustomFieldsValues `json:"custom_fields_values,omitempty"` //Значения дополнительных полей покупателя
+	Embedded           *CustomerEmbedded  `json:"_embedded,omitempty"`            //Данные вложенных сущностей
+	RequestID          string             `json:"request_id,omitempty"`           //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+type TransactionEmbedded struct {
+	Customer        *EmbeddedCustomer        `json:"customer,omitempty"`
+	CatalogElements []EmbeddedCatalogElement `json:"catalog_elements,omitempty"`
+}
+
+type Transaction struct {
+	ID          int                  `json:"id,omitempty"`
+	Comment     string               `json:"comment,omitempty"`      //Комментарий к покупке
+	Price       int                  `json:"price,omitempty"`        //Сумма покупки
+	CompletedAt int                  `json:"completed_at,omitempty"` //Когда транзакция была проведена, передается в Unix Timestamp
+	CustomerID  int                  `json:"customer_id,omitempty"`  //ID покупателя, в котором находится транзакция
+	CreatedBy   int                  `json:"created_by,omitempty"`   //ID пользователя, создавшего транзакцию
+	UpdatedBy   int                  `json:"updated_by,omitempty"`   //ID пользователя, изменившего транзакцию
+	CreatedAt   int                  `json:"created_at,omitempty"`   //Дата создания транзакции, передается в Unix Timestamp
+	UpdatedAt   int                  `json:"updated_at,omitempty"`   //Дата изменения транзакции, передается в Unix Timestamp
+	IsDeleted   bool                 `json:"is_deleted,omitempty"`   //Удалена ли транзакция
+	AccountID   int                  `json:"account_id,omitempty"`   //ID аккаунта, в котором находится транзакция
+	NextPrice   int                  `json:"next_price,omitempty"`   //Ожидаемая сумма следующей покупки, передается только при создании
+	NextDate    int                  `json:"next_date,omitempty"`    //Ожидаемая дата следующей покупки, передается только при создании
+	AccrueBonus bool                 `json:"accrue_bonus,omitempty"` //Начислять ли бонусные баллы по покупке, передается только при создании
+	Embedded    *TransactionEmbedded `json:"_embedded,omitempty"`
+	RequestID   string               `json:"request_id,omitempty"` //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+type CustomerStatus struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name,omitempty"`        //Название статуса
+	Sort       int    `json:"sort,omitempty"`        //Сортировка статуса
+	IsDefault  bool   `json:"is_default,omitempty"`  //Является ли статус дефолтным
+	IsEditable bool   `json:"is_editable,omitempty"` //Доступен ли статус для редактирования
+	Color      string `json:"color,omitempty"`       //Цвет статуса
+	Type       int    `json:"type,omitempty"`        //Тип статуса: 0 – обычный, 1 – закрытый, 2 – ожидаемая покупка
+	AccountID  int    `json:"account_id,omitempty"`  //ID аккаунта, в котором находится статус
+	RequestID  string `json:"request_id,omitempty"`  //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+type Segment struct {
+	ID                 int                `json:"id,omitempty"`
+	Name               string             `json:"name,omitempty"`                 //Название сегмента
+	Color              string             `json:"color,omitempty"`                //Цвет сегмента
+	Type               string             `json:"type,omitempty"`                 //Тип сегмента: static или dynamic
+	CustomersCount     int                `json:"customers_count,omitempty"`      //Количество покупателей в сегменте
+	CreatedAt          int                `json:"created_at,omitempty"`           //Дата создания сегмента, передается в Unix Timestamp
+	UpdatedAt          int                `json:"updated_at,omitempty"`           //Дата изменения сегмента, передается в Unix Timestamp
+	AccountID          int                `json:"account_id,omitempty"`           //ID аккаунта, в котором находится сегмент
+	CustomFieldsValues CustomFieldsValues `json:"custom_fields_values,omitempty"` //Значения дополнительных полей сегмента
+}
+
+// CustomersFilter describes parameters of Customers list request.
+type CustomersFilter struct {
+	Relations          []string
+	Query              string
+	IDs                []int
+	Names              []string
+	NextDate           *Range
+	CreatedAt          *Range
+	UpdatedAt          *Range
+	ResponsibleUserIDs []int
+	Order              *Order // by updated_at or id
+	Page               int
+	Limit              int // 250 at most
+}
+
+func (f CustomersFilter) values() (url.Values, error) {
+	q := url.Values{}
+	if err := addRelations(q, "customer", f.Relations, customerRelations...); err != nil {
+		return nil, err
+	}
+	if f.Query != "" {
+		q.Set("query", f.Query)
+	}
+	addInts(q, "filter[id]", f.IDs)
+	addStrings(q, "filter[name]", f.Names)
+	addRange(q, "filter[next_date]", f.NextDate)
+	addRange(q, "filter[created_at]", f.CreatedAt)
+	addRange(q, "filter[updated_at]", f.UpdatedAt)
+	addInts(q, "filter[responsible_user_id]", f.ResponsibleUserIDs)
+	if err := addOrder(q, f.Order, "updated_at", "id"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+// TransactionsFilter describes parameters of Transactions list request.
+type TransactionsFilter struct {
+	CustomerID int // transactions of all customers if zero
+	IDs        []int
+	Order      *Order // by created_at or id
+	Page       int
+	Limit      int // 250 at most
+}
+
+func (f TransactionsFilter) values() (url.Values, error) {
+	q := url.Values{}
+	addInts(q, "filter[id]", f.IDs)
+	if err := addOrder(q, f.Order, "created_at", "id"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+func (f TransactionsFilter) endpoint() endpoint {
+	if f.CustomerID != 0 {
+		return customersEndpoint.id(f.CustomerID) + "/transactions"
+	}
+
+	return customerTransactionsEndpoint
+}
+
+// Customers describes methods available for customers, their transactions,
+// statuses and segments. Every method fails with ErrCustomersDisabled
+// unless customers are turned on in the account.
+type Customers interface {
+	List(ctx context.Context, filter CustomersFilter) ([]Customer, error)
+	Iterate(filter CustomersFilter) *Iterator[Customer]
+	GetByID(ctx context.Context, id int, with ...string) (*Customer, error)
+	Create(ctx context.Context, customers []Customer) ([]Customer, error)
+	Update(ctx context.Context, customers []Customer) ([]Customer, error)
+
+	ListTransactions(ctx context.Context, filter TransactionsFilter) ([]Transaction, error)
+	IterateTransactions(filter TransactionsFilter) *Iterator[Transaction]
+	CreateTransactions(ctx context.Context, customerID int, transactions []Transaction) ([]Transaction, error)
+	DeleteTransaction(ctx context.Context, id int) error
+
+	ListStatuses(ctx context.Context) ([]CustomerStatus, error)
+	IterateStatuses() *Iterator[CustomerStatus]
+	GetStatus(ctx context.Context, id int) (*CustomerStatus, error)
+	CreateStatuses(ctx context.Context, statuses []CustomerStatus) ([]CustomerStatus, error)
+	UpdateStatus(ctx context.Context, status CustomerStatus) (*CustomerStatus, error)
+	DeleteStatus(ctx context.Context, id int) error
+
+	ListSegments(ctx context.Context) ([]Segment, error)
+	IterateSegments() *Iterator[Segment]
+	GetSegment(ctx context.Context, id int) (*Segment, error)
+	CreateSegments(ctx context.Context, segments []Segment) ([]Segment, error)
+	UpdateSegment(ctx context.Context, segment Segment) (*Segment, error)
+	DeleteSegment(ctx context.Context, id int) error
+}
+
+// Verify interface compliance.
+var _ Customers = customers{}
+
+type customers struct {
+	api *api
+}
+
+func newCustomers(api *api) Customers {
+	return customers{api: api}
+}
+
+// customersDisabledTTL is how long ErrCustomersDisabled is remembered, so
+// that turning customers on in the account takes effect without a restart.
+const customersDisabledTTL = time.Minute
+
+// customersGate remembers whether customers are enabled in the account.
+// It is shared by all Customers repositories of a client, so the account
+// is requested once and concurrent calls wait for the same request.
+// The result is bound to the domain it was checked for.
+type customersGate struct {
+	mu      sync.Mutex
+	checked bool
+	domain  string        // domain the result was checked for
+	expires time.Time     // zero unless err is ErrCustomersDisabled
+	err     error         // ErrCustomersDisabled or nil once checked
+	pending chan struct{} // closed when the in-flight check is over
+}
+
+func (g *customersGate) check(ctx context.Context, a *api) error {
+	domain := a.domain
+
+	g.mu.Lock()
+	for !g.valid(domain) && g.pending != nil {
+		pending := g.pending
+		g.mu.Unlock()
+		select {
+		case <-pending:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		g.mu.Lock()
+	}
+	if g.valid(domain) {
+		err := g.err
+		g.mu.Unlock()
+		return err
+	}
+	pending := make(chan struct{})
+	g.pending = pending
+	g.mu.Unlock()
+
+	err := customersMode(ctx, a)
+
+	g.mu.Lock()
+	// Failed requests are not remembered, the next call checks again.
+	switch {
+	case err == nil:
+		g.checked, g.domain, g.expires, g.err = true, domain, time.Time{}, nil
+	case errors.Is(err, ErrCustomersDisabled):
+		g.checked, g.domain, g.expires, g.err = true, domain, time.Now().Add(customersDisabledTTL), err
+	}
+	g.pending = nil
+	close(pending)
+	g.mu.Unlock()
+
+	return err
+}
+
+// valid reports whether the remembered result belongs to domain and has
+// not expired yet.
+func (g *customersGate) valid(domain string) bool {
+	if !g.checked || g.domain != domain {
+		return false
+	}
+
+	return g.expires.IsZero() || time.Now().Before(g.expires)
+}
+
+func customersMode(ctx context.Context, a *api) error {
+	account, err := newAccounts(a).CurrentContext(ctx, AccountsConfig{})
+	if err != nil {
+		return fmt.Errorf("check customers mode: %w", err)
+	}
+	if !account.CustomersEnabled() {
+		return fmt.Errorf("%w: customers mode is %s", ErrCustomersDisabled, account.CustomersMode)
+	}
+
+	return nil
+}
+
+func (a customers) List(ctx context.Context, filter CustomersFilter) ([]Customer, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Customer](ctx, a.api, customersEndpoint, query, "customers")
+	return items, err
+}
+
+// Iterate walks all customers matching the filter starting from filter.Page.
+func (a customers) Iterate(filter CustomersFilter) *Iterator[Customer] {
+	query, err := filter.values()
+	return iterateCustomers[Customer](a.api, customersEndpoint, "customers", filter.Page, query, err)
+}
+
+// iterateCustomers is like iterate but checks that customers are enabled
+// before every page.
+func iterateCustomers[T any](a *api, ep endpoint, key string, page int, q url.Values, qErr error) *Iterator[T] {
+	it := newIterator(page, func(ctx context.Context, page int) ([]T, bool, error) {
+		if err := a.customers.check(ctx, a); err != nil {
+			return nil, false, err
+		}
+
+		return listPage[T](ctx, a, ep, withPage(q, page), key)
+	})
+	it.err = qErr
+
+	return it
+}
+
+func (a customers) GetByID(ctx context.Context, id int, with ...string) (*Customer, error) {
+	query := url.Values{}
+	if err := addRelations(query, "customer", with, customerRelations...); err != nil {
+		return nil, err
+	}
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	customer := &Customer{}
+	if err := a.api.get(ctx, customersEndpoint.id(id), query, customer); err != nil {
+		return nil, fmt.Errorf("get customer %d: %w", id, err)
+	}
+
+	return customer, nil
+}
+
+func (a customers) Create(ctx context.Context, customers []Customer) ([]Customer, error) {
+	return a.save(ctx, http.MethodPost, customers)
+}
+
+func (a customers) Update(ctx context.Context, customers []Customer) ([]Customer, error) {
+	return a.save(ctx, http.MethodPatch, customers)
+}
+
+func (a customers) save(ctx context.Context, method string, customers []Customer) ([]Customer, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	resp, rErr := a.api.do(ctx, customersEndpoint, method, nil, nil, customers)
+	if rErr != nil {
+		return nil, fmt.Errorf("save customers: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Customers []Customer `json:"customers"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Customers, nil
+}
+
+func (a customers) ListTransactions(ctx context.Context, filter TransactionsFilter) ([]Transaction, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Transaction](ctx, a.api, filter.endpoint(), query, "transactions")
+	return items, err
+}
+
+// IterateTransactions walks all transactions matching the filter starting
+// from filter.Page.
+func (a customers) IterateTransactions(filter TransactionsFilter) *Iterator[Transaction] {
+	query, err := filter.values()
+	return iterateCustomers[Transaction](a.api, filter.endpoint(), "transactions", filter.Page, query, err)
+}
+
+// CreateTransactions adds purchases to the customer.
+func (a customers) CreateTransactions(ctx context.Context, customerID int, transactions []Transaction) ([]Transaction, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	ep := customersEndpoint.id(customerID) + "/transactions"
+	resp, rErr := a.api.do(ctx, ep, http.MethodPost, nil, nil, transactions)
+	if rErr != nil {
+		return nil, fmt.Errorf("create transactions: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Transactions []Transaction `json:"transactions"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Transactions, nil
+}
+
+func (a customers) DeleteTransaction(ctx context.Context, id int) error {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return err
+	}
+
+	return a.api.delete(ctx, customerTransactionsEndpoint.id(id))
+}
+
+// ListStatuses returns the first page of customer statuses, use
+// IterateStatuses to get all of them.
+func (a customers) ListStatuses(ctx context.Context) ([]CustomerStatus, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[CustomerStatus](ctx, a.api, customerStatusesEndpoint, nil, "statuses")
+	return items, err
+}
+
+// IterateStatuses walks all customer statuses.
+func (a customers) IterateStatuses() *Iterator[CustomerStatus] {
+	return iterateCustomers[CustomerStatus](a.api, customerStatusesEndpoint, "statuses", 1, nil, nil)
+}
+
+func (a customers) GetStatus(ctx context.Context, id int) (*CustomerStatus, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	status := &CustomerStatus{}
+	if err := a.api.get(ctx, customerStatusesEndpoint.id(id), nil, status); err != nil {
+		return nil, fmt.Errorf("get customer status %d: %w", id, err)
+	}
+
+	return status, nil
+}
+
+func (a customers) CreateStatuses(ctx context.Context, statuses []CustomerStatus) ([]CustomerStatus, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	resp, rErr := a.api.do(ctx, customerStatusesEndpoint, http.MethodPost, nil, nil, statuses)
+	if rErr != nil {
+		return nil, fmt.Errorf("create customer statuses: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Statuses []CustomerStatus `json:"statuses"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Statuses, nil
+}
+
+func (a customers) UpdateStatus(ctx context.Context, status CustomerStatus) (*CustomerStatus, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	resp, rErr := a.api.do(ctx, customerStatusesEndpoint.id(status.ID), http.MethodPatch, nil, nil, status)
+	if rErr != nil {
+		return nil, fmt.Errorf("update customer status: %w", rErr)
+	}
+
+	res := &CustomerStatus{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a customers) DeleteStatus(ctx context.Context, id int) error {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return err
+	}
+
+	return a.api.delete(ctx, customerStatusesEndpoint.id(id))
+}
+
+// ListSegments returns the first page of segments, use IterateSegments
+// to get all of them.
+func (a customers) ListSegments(ctx context.Context) ([]Segment, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Segment](ctx, a.api, customerSegmentsEndpoint, nil, "segments")
+	return items, err
+}
+
+// IterateSegments walks all segments.
+func (a customers) IterateSegments() *Iterator[Segment] {
+	return iterateCustomers[Segment](a.api, customerSegmentsEndpoint, "segments", 1, nil, nil)
+}
+
+func (a customers) GetSegment(ctx context.Context, id int) (*Segment, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	segment := &Segment{}
+	if err := a.api.get(ctx, customerSegmentsEndpoint.id(id), nil, segment); err != nil {
+		return nil, fmt.Errorf("get segment %d: %w", id, err)
+	}
+
+	return segment, nil
+}
+
+func (a customers) CreateSegments(ctx context.Context, segments []Segment) ([]Segment, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	resp, rErr := a.api.do(ctx, customerSegmentsEndpoint, http.MethodPost, nil, nil, segments)
+	if rErr != nil {
+		return nil, fmt.Errorf("create segments: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Segments []Segment `json:"segments"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Segments, nil
+}
+
+func (a customers) UpdateSegment(ctx context.Context, segment Segment) (*Segment, error) {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return nil, err
+	}
+
+	resp, rErr := a.api.do(ctx, customerSegmentsEndpoint.id(segment.ID), http.MethodPatch, nil, nil, segment)
+	if rErr != nil {
+		return nil, fmt.Errorf("update segment: %w", rErr)
+	}
+
+	res := &Segment{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a customers) DeleteSegment(ctx context.Context, id int) error {
+	if err := a.api.customers.check(ctx, a.api); err != nil {
+		return err
+	}
+
+	return a.api.delete(ctx, customerSegmentsEndpoint.id(id))
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomers_Disabled(t *testing.T) {
+	var accountRequests int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/accounts", r.URL.Path)
+		atomic.AddInt32(&accountRequests, 1)
+		_, _ = w.Write([]byte(`{"id": 1, "customers_mode": "disabled"}`))
+	}))
+
+	_, err := newCustomers(a).List(context.Background(), CustomersFilter{})
+	require.True(t, errors.Is(err, ErrCustomersDisabled), err)
+	require.EqualError(t, err, "customers are disabled: customers mode is disabled")
+
+	_, err = newCustomers(a).ListSegments(context.Background())
+	require.True(t, errors.Is(err, ErrCustomersDisabled), err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&accountRequests))
+}
+
+func TestCustomers_GateConcurrent(t *testing.T) {
+	var accountRequests int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/accounts":
+			if atomic.AddInt32(&accountRequests, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"id": 1, "customers_mode": "segments"}`))
+		case "/api/v4/customers/segments":
+			_, _ = w.Write([]byte(`{"_embedded": {"segments": [{"id": 1}]}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	// A failed check is not remembered.
+	_, err := newCustomers(a).ListSegments(context.Background())
+	require.True(t, errors.Is(err, ErrServerError), err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := newCustomers(a).ListSegments(context.Background())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.EqualValues(t, 2, atomic.LoadInt32(&accountRequests))
+}
+
+func TestCustomers_GateDomainAndTTL(t *testing.T) {
+	var (
+		accountRequests int32
+		enabled         int32 // customers of example.amocrm.ru
+	)
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/accounts":
+			atomic.AddInt32(&accountRequests, 1)
+			mode := "disabled"
+			if r.Host != testDomain || atomic.LoadInt32(&enabled) == 1 {
+				mode = "segments"
+			}
+			_, _ = fmt.Fprintf(w, `{"id": 1, "customers_mode": %q}`, mode)
+		case "/api/v4/customers/segments":
+			_, _ = w.Write([]byte(`{"_embedded": {"segments": []}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	ctx := context.Background()
+
+	_, err := newCustomers(a).ListSegments(ctx)
+	require.True(t, errors.Is(err, ErrCustomersDisabled), err)
+
+	// Another account is checked on its own.
+	require.NoError(t, a.setDomain("other.amocrm.ru"))
+	_, err = newCustomers(a).ListSegments(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&accountRequests))
+
+	require.NoError(t, a.setDomain(testDomain))
+	_, err = newCustomers(a).ListSegments(ctx)
+	require.True(t, errors.Is(err, ErrCustomersDisabled), err)
+	require.EqualValues(t, 3, atomic.LoadInt32(&accountRequests))
+
+	atomic.StoreInt32(&enabled, 1)
+	_, err = newCustomers(a).ListSegments(ctx)
+	require.True(t, errors.Is(err, ErrCustomersDisabled), err)
+	require.EqualValues(t, 3, atomic.LoadInt32(&accountRequests))
+
+	// Disabled customers are checked again once the result expires.
+	a.customers.mu.Lock()
+	a.customers.expires = time.Now().Add(-time.Second)
+	a.customers.mu.Unlock()
+	_, err = newCustomers(a).ListSegments(ctx)
+	require.NoError(t, err)
+	_, err = newCustomers(a).ListSegments(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, atomic.LoadInt32(&accountRequests))
+}
+
+func TestCustomers_Transactions(t *testing.T) {
+	var accountRequests int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/accounts":
+			atomic.AddInt32(&accountRequests, 1)
+			_, _ = w.Write([]byte(`{"id": 1, "customers_mode": "periodicity"}`))
+		case "/api/v4/customers/7/transactions":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `[{"price": 990, "comment": "Заказ 15", "accrue_bonus": true, "next_date": 1600000000}]`, string(body))
+			_, _ = w.Write([]byte(`{"_embedded": {"transactions": [{"id": 3, "price": 990, "customer_id": 7}]}}`))
+		case "/api/v4/customers/transactions/3":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	items, err := newCustomers(a).CreateTransactions(context.Background(), 7, []Transaction{
+		{Price: 990, Comment: "Заказ 15", AccrueBonus: true, NextDate: 1600000000},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Transaction{{ID: 3, Price: 990, CustomerID: 7}}, items)
+
+	require.NoError(t, newCustomers(a).DeleteTransaction(context.Background(), 3))
+	require.EqualValues(t, 1, atomic.LoadInt32(&accountRequests))
+}
+
+func TestCustomers_IterateTransactions(t *testing.T) {
+	var accountRequests int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/accounts":
+			atomic.AddInt32(&accountRequests, 1)
+			_, _ = w.Write([]byte(`{"id": 1, "customers_mode": "periodicity"}`))
+		case "/api/v4/customers/7/transactions":
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			switch r.URL.Query().Get("page") {
+			case "1":
+				_, _ = w.Write([]byte(`{"_links": {"next": {"href": ""}}, "_embedded": {"transactions": [{"id": 3, "customer_id": 7}]}}`))
+			case "2":
+				_, _ = w.Write([]byte(`{"_embedded": {"transactions": [{"id": 4, "customer_id": 7}]}}`))
+			default:
+				t.Errorf("unexpected page %s", r.URL.Query().Get("page"))
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	items, err := Collect(context.Background(), newCustomers(a).IterateTransactions(TransactionsFilter{CustomerID: 7, Limit: 1}))
+	require.NoError(t, err)
+	require.Equal(t, []Transaction{{ID: 3, CustomerID: 7}, {ID: 4, CustomerID: 7}}, items)
+	require.EqualValues(t, 1, atomic.LoadInt32(&accountRequests))
+
+	_, err = Collect(context.Background(), newCustomers(a).IterateTransactions(TransactionsFilter{Order: &Order{Field: "price"}}))
+	require.Error(t, err)
+}
+
+func TestCustomers_IterateSegments(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/api/v4/accounts?":
+			_, _ = w.Write([]byte(`{"id": 1, "customers_mode": "segments"}`))
+		case "/api/v4/customers/segments?page=1":
+			_, _ = w.Write([]byte(`{"_links": {"next": {"href": ""}}, "_embedded": {"segments": [{"id": 1}]}}`))
+		case "/api/v4/customers/segments?page=2":
+			_, _ = w.Write([]byte(`{"_embedded": {"segments": [{"id": 2}]}}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+
+	items, err := Collect(context.Background(), newCustomers(a).IterateSegments())
+	require.NoError(t, err)
+	require.Equal(t, []Segment{{ID: 1}, {ID: 2}}, items)
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	eventsEndpoint endpoint = "events"
+)
+
+// Relations available for Events.
+const (
+	WithContactName        = "contact_name"
+	WithLeadName           = "lead_name"
+	WithCompanyName        = "company_name"
+	WithCatalogElementName = "catalog_element_name"
+	WithCustomerName       = "customer_name"
+	WithCatalogName        = "catalog_name"
+)
+
+var eventRelations = []string{
+	WithContactName,
+	WithLeadName,
+	WithCompanyName,
+	WithCatalogElementName,
+	WithCustomerName,
+	WithCatalogName,
+}
+
+// Types of events, see Events.Types for the full list.
+const (
+	EventLeadAdded                = "lead_added"
+	EventLeadDeleted              = "lead_deleted"
+	EventLeadRestored             = "lead_restored"
+	EventLeadStatusChanged        = "lead_status_changed"
+	EventLeadLinked               = "lead_linked"
+	EventLeadUnlinked             = "lead_unlinked"
+	EventContactAdded             = "contact_added"
+	EventContactDeleted           = "contact_deleted"
+	EventCompanyAdded             = "company_added"
+	EventCompanyDeleted           = "company_deleted"
+	EventCustomerAdded            = "customer_added"
+	EventCustomerStatusChanged    = "customer_status_changed"
+	EventTaskAdded                = "task_added"
+	EventTaskCompleted            = "task_completed"
+	EventIncomingCall             = "incoming_call"
+	EventOutgoingCall             = "outgoing_call"
+	EventIncomingChatMessage      = "incoming_chat_message"
+	EventOutgoingChatMessage      = "outgoing_chat_message"
+	EventEntityResponsibleChanged = "entity_responsible_changed"
+	EventEntityTagAdded           = "entity_tag_added"
+	EventEntityTagDeleted         = "entity_tag_deleted"
+	EventSaleFieldChanged         = "sale_field_changed"
+	EventNameFieldChanged         = "name_field_changed"
+	EventCustomFieldValueChanged  = "custom_field_value_changed"
+)
+
+type EventID struct {
+	ID int `json:"id"`
+}
+
+type EventLeadStatus struct {
+	ID         int `json:"id"`          //ID статуса
+	PipelineID int `json:"pipeline_id"` //ID воронки
+}
+
+type EventCustomFieldValue struct {
+	FieldID   int    `json:"field_id"`          //ID поля
+	FieldType int    `json:"field_type"`        //Тип поля
+	EnumID    int    `json:"enum_id,omitempty"` //ID варианта значения
+	Text      string `json:"text"`              //Значение поля
+}
+
+type EventSaleValue struct {
+	Sale int `json:"sale"` //Бюджет
+}
+
+type EventNameValue struct {
+	Name string `json:"name"` //Название
+}
+
+type EventMessage struct {
+	ID     string `json:"id"`      //ID сообщения
+	Origin string `json:"origin"`  //Источник сообщения
+	TalkID int    `json:"talk_id"` //ID беседы
+}
+
+type EventLink struct {
+	Entity struct {
+		ID   int        `json:"id"`
+		Type EntityType `json:"type"`
+	} `json:"entity"` //Привязанная сущность
+}
+
+// EventValue is an item of value_before or value_after of an event.
+// Only the field of the event kind is set.
+type EventValue struct {
+	LeadStatus       *EventLeadStatus       `json:"lead_status,omitempty"`        //Статус сделки
+	CustomerStatus   *EventID               `json:"customer_status,omitempty"`    //Статус покупателя
+	ResponsibleUser  *EventID               `json:"responsible_user,omitempty"`   //Ответственный пользователь
+	Note             *EventID               `json:"note,omitempty"`               //Примечание
+	Task             *EventID               `json:"task,omitempty"`               //Задача
+	Transaction      *EventID               `json:"transaction,omitempty"`        //Покупка
+	Tag              *Tag                   `json:"tag,omitempty"`                //Тег
+	CustomFieldValue *EventCustomFieldValue `json:"custom_field_value,omitempty"` //Значение дополнительного поля
+	SaleFieldValue   *EventSaleValue        `json:"sale_field_value,omitempty"`   //Бюджет
+	NameFieldValue   *EventNameValue        `json:"name_field_value,omitempty"`   //Название
+	Message          *EventMessage          `json:"message,omitempty"`            //Сообщение чата
+	Link             *EventLink             `json:"link,omitempty"`               //Связь с сущностью
+}
+
+type AuditEventEntity struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"` //Название сущности. Возвращается при запросе с *_name
+}
+
+type AuditEventEmbedded struct {
+	Entity *AuditEventEntity `json:"entity,omitempty"`
+}
+
+// AuditEvent is an event of the account audit log.
+type AuditEvent struct {
+	ID          string              `json:"id"`
+	Type        string              `json:"type"`         //Тип события
+	EntityID    int                 `json:"entity_id"`    //ID сущности события
+	EntityType  string              `json:"entity_type"`  //Сущность события (lead, contact, company, customer, task, catalog_{id})
+	CreatedBy   int                 `json:"created_by"`   //ID пользователя, создавшего событие
+	CreatedAt   int                 `json:"created_at"`   //Дата создания события, передается в Unix Timestamp
+	ValueAfter  []EventValue        `json:"value_after"`  //Значение после события
+	ValueBefore []EventValue        `json:"value_before"` //Значение до события
+	AccountID   int                 `json:"account_id"`   //ID аккаунта, в котором находится событие
+	Embedded    *AuditEventEmbedded `json:"_embedded,omitempty"`
+}
+
+// StatusChange returns lead statuses of lead_status_changed events.
+func (e AuditEvent) StatusChange() (before, after *EventLeadStatus, ok bool) {
+	for _, v := range e.ValueBefore {
+		if v.LeadStatus != nil {
+			before = v.LeadStatus
+		}
+	}
+	for _, v := range e.ValueAfter {
+		if v.LeadStatus != nil {
+			after = v.LeadStatus
+		}
+	}
+
+	return before, after, before != nil && after != nil
+}
+
+// AuditEventType describes a type of events.
+type AuditEventType struct {
+	Key  string            `json:"key"`  //Ключ типа события
+	Type int               `json:"type"` //Код типа события
+	Lang map[string]string `json:"lang"` //Название типа события на языках аккаунта
+}
+
+// EventValueFilter filters events by values before or after them.
+type EventValueFilter struct {
+	LeadStatuses      []StatusFilter
+	CustomerStatusIDs []int
+	ResponsibleUserID int
+}
+
+func (f *EventValueFilter) add(q url.Values, key string) {
+	if f == nil {
+		return
+	}
+
+	for i, status := range f.LeadStatuses {
+		k := fmt.Sprintf("%s[leads_statuses][%d]", key, i)
+		q.Set(k+"[pipeline_id]", strconv.Itoa(status.PipelineID))
+		q.Set(k+"[status_id]", strconv.Itoa(status.StatusID))
+	}
+	for i, id := range f.CustomerStatusIDs {
+		q.Set(fmt.Sprintf("%s[customers_statuses][%d][status_id]", key, i), strconv.Itoa(id))
+	}
+	if f.ResponsibleUserID != 0 {
+		q.Set(key+"[responsible_user_id]", strconv.Itoa(f.ResponsibleUserID))
+	}
+}
+
+// EventsFilter describes parameters of Events list request.
+type EventsFilter struct {
+	Relations   []string
+	IDs         []string
+	Types       []string
+	Entities    []string // lead, contact, company, customer, task or catalog_{id}
+	EntityIDs   []int    // requires a single entity in Entities
+	CreatedAt   *Range
+	CreatedBy   []int
+	ValueBefore *EventValueFilter
+	ValueAfter  *EventValueFilter
+	Page        int
+	Limit       int // 100 at most
+}
+
+func (f EventsFilter) values() (url.Values, error) {
+	q := url.Values{}
+	if err := addRelations(q, "event", f.Relations, eventRelations...); err != nil {
+		return nil, err
+	}
+	addStrings(q, "filter[id]", f.IDs)
+	if len(f.Types) > 0 {
+		q.Set("filter[type]", strings.Join(f.Types, ","))
+	}
+	if len(f.Entities) > 0 {
+		q.Set("filter[entity]", strings.Join(f.Entities, ","))
+	}
+	addInts(q, "filter[entity_id]", f.EntityIDs)
+	addRange(q, "filter[created_at]", f.CreatedAt)
+	addInts(q, "filter[created_by]", f.CreatedBy)
+	f.ValueBefore.add(q, "filter[value_before]")
+	f.ValueAfter.add(q, "filter[value_after]")
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+// Events describes methods available for the account audit log.
+type Events interface {
+	List(ctx context.Context, filter EventsFilter) ([]AuditEvent, error)
+	Iterate(filter EventsFilter) *Iterator[AuditEvent]
+	GetByID(ctx context.Context, id string, with ...string) (*AuditEvent, error)
+	Types(ctx context.Context, language string) ([]AuditEventType, error)
+}
+
+// Verify interface compliance.
+var _ Events = events{}
+
+type events struct {
+	api *api
+}
+
+func newEvents(api *api) Events {
+	return events{api: api}
+}
+
+func (a events) List(ctx context.Context, filter EventsFilter) ([]AuditEvent, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[AuditEvent](ctx, a.api, eventsEndpoint, query, "events")
+	return items, err
+}
+
+// Iterate walks all events matching the filter starting from filter.Page.
+func (a events) Iterate(filter EventsFilter) *Iterator[AuditEvent] {
+	query, err := filter.values()
+	return iterate[AuditEvent](a.api, eventsEndpoint, "events", filter.Page, query, err)
+}
+
+func (a events) GetByID(ctx context.Context, id string, with ...string) (*AuditEvent, error) {
+	query := url.Values{}
+	if err := addRelations(query, "event", with, eventRelations...); err != nil {
+		return nil, err
+	}
+
+	event := &AuditEvent{}
+	if err := a.api.get(ctx, eventsEndpoint+endpoint("/"+url.PathEscape(id)), query, event); err != nil {
+		return nil, fmt.Errorf("get event %s: %w", id, err)
+	}
+
+	return event, nil
+}
+
+// Types returns types of events with names in the language, e.g. ru or en.
+// Empty language means the account one. amoCRM returns all types at once.
+func (a events) Types(ctx context.Context, language string) ([]AuditEventType, error) {
+	query := url.Values{}
+	if language != "" {
+		query.Set("language_code", language)
+	}
+
+	items, _, err := listPage[AuditEventType](ctx, a.api, eventsEndpoint+"/types", query, "events_types")
+	return items, err
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents_List(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/events", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, []string{WithLeadName}, q["with"])
+		assert.Equal(t, "lead_status_changed,entity_responsible_changed", q.Get("filter[type]"))
+		assert.Equal(t, "lead", q.Get("filter[entity]"))
+		assert.Equal(t, []string{"7"}, q["filter[entity_id][]"])
+		assert.Equal(t, "3", q.Get("filter[value_after][leads_statuses][0][pipeline_id]"))
+		assert.Equal(t, "142", q.Get("filter[value_after][leads_statuses][0][status_id]"))
+		_, _ = w.Write([]byte(`{"_embedded": {"events": [
+			{
+				"id": "01pz58t6p04b0yf6hc3ac7xsgg", "type": "lead_status_changed", "entity_id": 7, "entity_type": "lead",
+				"created_by": 504141, "created_at": 1600000000, "account_id": 1,
+				"value_before": [{"lead_status": {"id": 10, "pipeline_id": 3}}],
+				"value_after": [{"lead_status": {"id": 142, "pipeline_id": 3}}],
+				"_embedded": {"entity": {"id": 7, "name": "Сделка"}}
+			},
+			{
+				"id": "01pz58t6p04b0yf6hc3ac7xsgh", "type": "entity_responsible_changed", "entity_id": 7, "entity_type": "lead",
+				"value_before": [{"responsible_user": {"id": 1}}],
+				"value_after": [{"responsible_user": {"id": 2}}]
+			}
+		]}}`))
+	}))
+
+	items, err := events{api: a}.List(context.Background(), EventsFilter{
+		Relations:  []string{WithLeadName},
+		Types:      []string{EventLeadStatusChanged, EventEntityResponsibleChanged},
+		Entities:   []string{"lead"},
+		EntityIDs:  []int{7},
+		ValueAfter: &EventValueFilter{LeadStatuses: []StatusFilter{{PipelineID: 3, StatusID: StatusWon}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	before, after, ok := items[0].StatusChange()
+	require.True(t, ok)
+	require.Equal(t, &EventLeadStatus{ID: 10, PipelineID: 3}, before)
+	require.Equal(t, &EventLeadStatus{ID: StatusWon, PipelineID: 3}, after)
+	require.Equal(t, "Сделка", items[0].Embedded.Entity.Name)
+
+	_, _, ok = items[1].StatusChange()
+	require.False(t, ok)
+	require.Equal(t, 2, items[1].ValueAfter[0].ResponsibleUser.ID)
+}
+
+func TestEvents_Types(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/events/types", r.URL.Path)
+		assert.Equal(t, "en", r.URL.Query().Get("language_code"))
+		_, _ = w.Write([]byte(`{"_embedded": {"events_types": [
+			{"key": "lead_added", "type": 1, "lang": {"en": "Lead added"}}
+		]}}`))
+	}))
+
+	items, err := events{api: a}.Types(context.Background(), "en")
+	require.NoError(t, err)
+	require.Equal(t, []AuditEventType{{Key: EventLeadAdded, Type: 1, Lang: map[string]string{"en": "Lead added"}}}, items)
+}
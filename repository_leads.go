@@ -23,37 +23,168 @@
 package amocrm
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
+// Relations available for Leads.
+const (
+	WithContacts               = "contacts"
+	WithCatalogElements        = "catalog_elements"
+	WithIsPriceModifiedByRobot = "is_price_modified_by_robot"
+	WithLossReason             = "loss_reason"
+	WithOnlyDeleted            = "only_deleted"
+	WithSourceID               = "source_id"
+)
+
+var leadRelations = []string{
+	WithContacts,
+	WithCatalogElements,
+	WithIsPriceModifiedByRobot,
+	WithLossReason,
+	WithOnlyDeleted,
+	WithSourceID,
+}
+
 type LeadEmbedded struct {
-	Tags      []FieldValues `json:"tags,omitempty"`
-	Contacts  []FieldValues `json:"contacts,omitempty"`
-	Companies []FieldValues `json:"companies,omitempty"`
+	Tags            []Tag                    `json:"tags,omitempty"`
+	Contacts        []EmbeddedContact        `json:"contacts,omitempty"`
+	Companies       []EmbeddedCompany        `json:"companies,omitempty"`
+	CatalogElements []EmbeddedCatalogElement `json:"catalog_elements,omitempty"`
+	LossReason      []LossReason             `json:"loss_reason,omitempty"`
 }
 
 type Lead struct {
-	Id                 int           `json:"id,omitempty"`
-	Name               string        `json:"name,omitempty"`                 //Название сделки. Поле не является обязательным
-	Price              int           `json:"price,omitempty"`                //Бюджет сделки. Поле не является обязательным
-	StatusId           int           `json:"status_id,omitempty"`            //ID статуса, в который добавляется сделка. Поле не является обязательным, по-умолчанию – первый этап главной воронки
-	PipelineId         int           `json:"pipeline_id,omitempty"`          //ID воронки, в которую добавляется сделка. Поле не является обязательным
-	CreatedBy          int           `json:"created_by,omitempty"`           //ID пользователя, создающий сделку. При передаче значения 0, сделка будет считаться созданной роботом. Поле не является обязательным
-	UpdatedBy          int           `json:"updated_by,omitempty"`           //ID пользователя, изменяющий сделку. При передаче значения 0, сделка будет считаться измененной роботом. Поле не является обязательным
-	ClosedAt           int           `json:"closed_at,omitempty"`            //Дата закрытия сделки, передается в Unix Timestamp. Поле не является обязательным
-	CreatedAt          int           `json:"created_at,omitempty"`           //Дата создания сделки, передается в Unix Timestamp. Поле не является обязательным
-	UpdatedAt          int           `json:"updated_at,omitempty"`           //Дата изменения сделки, передается в Unix Timestamp. Поле не является обязательным
-	LossReasonId       int           `json:"loss_reason_id,omitempty"`       //ID причины отказа. Поле не является обязательным
-	ResponsibleUserId  int           `json:"responsible_user_id,omitempty"`  //ID пользователя, ответственного за сделку. Поле не является обязательным
-	CustomFieldsValues []FieldValues `json:"custom_fields_values,omitempty"` //Массив, содержащий информацию по дополнительным полям, заданным для данной сделки. Поле не является обязательным. Примеры заполнения полей
-	Embedded           *LeadEmbedded `json:"_embedded,omitempty"`            //Данные вложенных сущностей, при создании и редактировании можно передать только теги. Поле не является обязательным
+	Id                     int                `json:"id,omitempty"`
+	Name                   string             `json:"name,omitempty"`                       //Название сделки. Поле не является обязательным
+	Price                  int                `json:"price,omitempty"`                      //Бюджет сделки. Поле не является обязательным
+	StatusId               int                `json:"status_id,omitempty"`                  //ID статуса, в который добавляется сделка. Поле не является обязательным, по-умолчанию – первый этап главной воронки
+	PipelineId             int                `json:"pipeline_id,omitempty"`                //ID воронки, в которую добавляется сделка. Поле не является обязательным
+	CreatedBy              int                `json:"created_by,omitempty"`                 //ID пользователя, создающий сделку. При передаче значения 0, сделка будет считаться созданной роботом. Поле не является обязательным
+	UpdatedBy              int                `json:"updated_by,omitempty"`                 //ID пользователя, изменяющий сделку. При передаче значения 0, сделка будет считаться измененной роботом. Поле не является обязательным
+	ClosedAt               int                `json:"closed_at,omitempty"`                  //Дата закрытия сделки, передается в Unix Timestamp. Поле не является обязательным
+	CreatedAt              int                `json:"created_at,omitempty"`                 //Дата создания сделки, передается в Unix Timestamp. Поле не является обязательным
+	UpdatedAt              int                `json:"updated_at,omitempty"`                 //Дата изменения сделки, передается в Unix Timestamp. Поле не является обязательным
+	LossReasonId           int                `json:"loss_reason_id,omitempty"`             //ID причины отказа. Поле не является обязательным
+	ResponsibleUserId      int                `json:"responsible_user_id,omitempty"`        //ID пользователя, ответственного за сделку. Поле не является обязательным
+	GroupId                int                `json:"group_id,omitempty"`                   //ID группы, в которой состоит ответственный пользователь за сделку
+	SourceId               int                `json:"source_id,omitempty"`                  //ID источника сделки. Возвращается при запросе с source_id
+	ClosestTaskAt          int                `json:"closest_task_at,omitempty"`            //Дата ближайшей задачи к выполнению, передается в Unix Timestamp
+	IsDeleted              bool               `json:"is_deleted,omitempty"`                 //Удалена ли сделка
+	Score                  int                `json:"score,omitempty"`                      //Скоринг сделки
+	AccountId              int                `json:"account_id,omitempty"`                 //ID аккаунта, в котором находится сделка
+	LaborCost              int                `json:"labor_cost,omitempty"`                 //Тип поля "стоимость труда" показывает сколько времени было затрачено на работу со сделкой
+	IsPriceModifiedByRobot bool               `json:"is_price_modified_by_robot,omitempty"` //Изменен ли в последний раз бюджет сделки роботом. Возвращается при запросе с is_price_modified_by_robot
+	CustomFieldsValues     CustomFieldsValues `json:"custom_fields_values,omitempty"`       //Массив, содержащий информацию по дополнительным полям, заданным для данной сделки. Поле не является обязательным. Примеры заполнения полей
+	Embedded               *LeadEmbedded      `json:"_embedded,omitempty"`                  //Данные вложенных сущностей, при создании и редактировании можно передать только теги. Поле не является обязательным
+	RequestID              string             `json:"request_id,omitempty"`                 //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+// LeadSource is a source of a lead created with the complex request.
+type LeadSource struct {
+	ExternalID int    `json:"external_id"` //Внешний ID источника
+	Type       string `json:"type"`        //Тип источника
+}
+
+type ComplexLeadEmbedded struct {
+	Contacts  []Contact       `json:"contacts,omitempty"`  //Новый контакт сделки, только один
+	Companies []Company       `json:"companies,omitempty"` //Новая компания сделки, только одна
+	Tags      []Tag           `json:"tags,omitempty"`      //Теги сделки
+	Source    *LeadSource     `json:"source,omitempty"`    //Источник сделки
+	Metadata  json.RawMessage `json:"metadata,omitempty"`  //Метаданные заявки, заполняются SetMetadata
+}
+
+// SetMetadata fills metadata of the forms or sip request the lead comes from.
+func (e *ComplexLeadEmbedded) SetMetadata(metadata UnsortedMetadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	fields["category"] = metadata.Category()
+
+	e.Metadata, err = json.Marshal(fields)
+	return err
+}
+
+// ComplexLead is a lead created together with its contact and company.
+type ComplexLead struct {
+	Lead
+	Embedded *ComplexLeadEmbedded `json:"_embedded,omitempty"`
+}
+
+// ComplexLeadResult holds IDs of entities created for a ComplexLead.
+type ComplexLeadResult struct {
+	ID        int      `json:"id"`         //ID созданной сделки
+	ContactID int      `json:"contact_id"` //ID созданного или найденного контакта
+	CompanyID int      `json:"company_id"` //ID созданной или найденной компании
+	RequestID []string `json:"request_id"` //Переданные request_id
+	Merged    bool     `json:"merged"`     //Был ли контакт объединен с существующим
+}
+
+// LeadsFilter describes parameters of Leads list request.
+type LeadsFilter struct {
+	Relations          []string
+	Query              string
+	IDs                []int
+	Names              []string
+	Price              *Range
+	Statuses           []StatusFilter
+	CreatedAt          *Range
+	UpdatedAt          *Range
+	ClosedAt           *Range
+	ResponsibleUserIDs []int
+	Order              *Order // by created_at, updated_at or id
+	Page               int
+	Limit              int // 250 at most
+}
+
+func (f LeadsFilter) values() (url.Values, error) {
+	q := url.Values{}
+	if err := addRelations(q, "lead", f.Relations, leadRelations...); err != nil {
+		return nil, err
+	}
+	if f.Query != "" {
+		q.Set("query", f.Query)
+	}
+	addInts(q, "filter[id]", f.IDs)
+	addStrings(q, "filter[name]", f.Names)
+	addRange(q, "filter[price]", f.Price)
+	for i, status := range f.Statuses {
+		key := fmt.Sprintf("filter[statuses][%d]", i)
+		q.Set(key+"[pipeline_id]", strconv.Itoa(status.PipelineID))
+		q.Set(key+"[status_id]", strconv.Itoa(status.StatusID))
+	}
+	addRange(q, "filter[created_at]", f.CreatedAt)
+	addRange(q, "filter[updated_at]", f.UpdatedAt)
+	addRange(q, "filter[closed_at]", f.ClosedAt)
+	addInts(q, "filter[responsible_user_id]", f.ResponsibleUserIDs)
+	if err := addOrder(q, f.Order, "created_at", "updated_at", "id"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
 }
 
 // Leads describes methods available for Leads entity.
 type Leads interface {
 	Create(leads []Lead) ([]Lead, error)
+	CreateContext(ctx context.Context, leads []Lead) ([]Lead, error)
 	Update(leads []Lead) ([]Lead, error)
+	UpdateContext(ctx context.Context, leads []Lead) ([]Lead, error)
+	List(ctx context.Context, filter LeadsFilter) ([]Lead, error)
+	Iterate(filter LeadsFilter) *Iterator[Lead]
+	GetByID(ctx context.Context, id int, with ...string) (*Lead, error)
+	CreateComplex(ctx context.Context, leads []ComplexLead) ([]ComplexLeadResult, error)
 }
 
 // Verify interface compliance.
@@ -69,7 +200,11 @@ func newLeads(api *api) Leads {
 
 // Current returns an Leads entity for current authorized user.
 func (a leads) Create(leads []Lead) ([]Lead, error) {
-	resp, rErr := a.api.do(leadsEndpoint, http.MethodPost, nil, nil, leads)
+	return a.CreateContext(context.Background(), leads)
+}
+
+func (a leads) CreateContext(ctx context.Context, leads []Lead) ([]Lead, error) {
+	resp, rErr := a.api.do(ctx, leadsEndpoint, http.MethodPost, nil, nil, leads)
 	if rErr != nil {
 		return nil, fmt.Errorf("get leads: %w", rErr)
 	}
@@ -88,7 +223,11 @@ func (a leads) Create(leads []Lead) ([]Lead, error) {
 
 // Current returns an Leads entity for current authorized user.
 func (a leads) Update(leads []Lead) ([]Lead, error) {
-	resp, rErr := a.api.do(leadsEndpoint, http.MethodPatch, nil, nil, leads)
+	return a.UpdateContext(context.Background(), leads)
+}
+
+func (a leads) UpdateContext(ctx context.Context, leads []Lead) ([]Lead, error) {
+	resp, rErr := a.api.do(ctx, leadsEndpoint, http.MethodPatch, nil, nil, leads)
 	if rErr != nil {
 		return nil, fmt.Errorf("get leads: %w", rErr)
 	}
@@ -104,3 +243,49 @@ func (a leads) Update(leads []Lead) ([]Lead, error) {
 
 	return res.Embedded.Leads, nil
 }
+
+func (a leads) List(ctx context.Context, filter LeadsFilter) ([]Lead, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Lead](ctx, a.api, leadsEndpoint, query, "leads")
+	return items, err
+}
+
+// Iterate walks all leads matching the filter starting from filter.Page.
+func (a leads) Iterate(filter LeadsFilter) *Iterator[Lead] {
+	query, err := filter.values()
+	return iterate[Lead](a.api, leadsEndpoint, "leads", filter.Page, query, err)
+}
+
+func (a leads) GetByID(ctx context.Context, id int, with ...string) (*Lead, error) {
+	query := url.Values{}
+	if err := addRelations(query, "lead", with, leadRelations...); err != nil {
+		return nil, err
+	}
+
+	lead := &Lead{}
+	if err := a.api.get(ctx, leadsEndpoint.id(id), query, lead); err != nil {
+		return nil, fmt.Errorf("get lead %d: %w", id, err)
+	}
+
+	return lead, nil
+}
+
+// CreateComplex creates leads with new contacts and companies in one
+// request. Results follow the order of leads. Up to 50 leads at once.
+func (a leads) CreateComplex(ctx context.Context, leads []ComplexLead) ([]ComplexLeadResult, error) {
+	resp, rErr := a.api.do(ctx, leadsEndpoint+"/complex", http.MethodPost, nil, nil, leads)
+	if rErr != nil {
+		return nil, fmt.Errorf("create complex leads: %w", rErr)
+	}
+
+	var res []ComplexLeadResult
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeads_CreateComplex(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v4/leads/complex", r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{
+			"name": "+79185436238",
+			"request_id": "row-1",
+			"_embedded": {
+				"contacts": [{"first_name": "Иван", "custom_fields_values": [{"field_code": "PHONE", "values": [{"value": "+79185436238"}]}]}],
+				"tags": [{"name": "partner_reg"}],
+				"metadata": {"category": "forms", "form_id": "1", "form_name": "Заявка", "form_page": "https://example.com", "form_sent_at": 1600000000}
+			}
+		}]`, string(body))
+
+		_, _ = w.Write([]byte(`[{"id": 10, "contact_id": 11, "company_id": null, "request_id": ["row-1"], "merged": true}]`))
+	}))
+
+	embedded := &ComplexLeadEmbedded{
+		Contacts: []Contact{{
+			FirstName: "Иван",
+			CustomFieldsValues: CustomFieldsValues{
+				{FieldCode: "PHONE", Values: []CustomFieldItem{TextValue("+79185436238")}},
+			},
+		}},
+		Tags: []Tag{{Name: "partner_reg"}},
+	}
+	require.NoError(t, embedded.SetMetadata(FormsMetadata{
+		FormID:     "1",
+		FormName:   "Заявка",
+		FormPage:   "https://example.com",
+		FormSentAt: 1600000000,
+	}))
+
+	results, err := leads{api: a}.CreateComplex(context.Background(), []ComplexLead{{
+		Lead:     Lead{Name: "+79185436238", RequestID: "row-1"},
+		Embedded: embedded,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []ComplexLeadResult{{ID: 10, ContactID: 11, RequestID: []string{"row-1"}, Merged: true}}, results)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeads_List(t *testing.T) {
+	var query url.Values
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads", r.URL.Path)
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{
+			"_page": 2,
+			"_links": {"self": {"href": "https://example.amocrm.ru/api/v4/leads?page=2"}},
+			"_embedded": {"leads": [{
+				"id": 19619,
+				"name": "Сделка для примера",
+				"price": 46333,
+				"status_id": 143,
+				"pipeline_id": 1300,
+				"is_deleted": false,
+				"_embedded": {
+					"contacts": [{"id": 7143599, "is_main": true}],
+					"companies": [{"id": 1}],
+					"loss_reason": [{"id": 12, "name": "Дорого"}]
+				}
+			}]}
+		}`))
+	}))
+
+	got, err := leads{api: a}.List(context.Background(), LeadsFilter{
+		Relations:          []string{WithContacts, WithLossReason},
+		Query:              "пример",
+		IDs:                []int{19619, 19620},
+		Price:              &Range{From: 100, To: 50000},
+		Statuses:           []StatusFilter{{PipelineID: 1300, StatusID: 143}},
+		CreatedAt:          &Range{From: 1600000000},
+		ResponsibleUserIDs: []int{504141},
+		Order:              &Order{Field: "created_at", Direction: OrderDesc},
+		Page:               2,
+		Limit:              50,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, url.Values{
+		"with":                             {WithContacts + "," + WithLossReason},
+		"query":                            {"пример"},
+		"filter[id][]":                     {"19619", "19620"},
+		"filter[price][from]":              {"100"},
+		"filter[price][to]":                {"50000"},
+		"filter[statuses][0][pipeline_id]": {"1300"},
+		"filter[statuses][0][status_id]":   {"143"},
+		"filter[created_at][from]":         {"1600000000"},
+		"filter[responsible_user_id][]":    {"504141"},
+		"order[created_at]":                {"desc"},
+		"page":                             {"2"},
+		"limit":                            {"50"},
+	}, query)
+
+	require.Len(t, got, 1)
+	require.Equal(t, 19619, got[0].Id)
+	require.Equal(t, []EmbeddedContact{{Id: 7143599, IsMain: true}}, got[0].Embedded.Contacts)
+	require.Equal(t, "Дорого", got[0].Embedded.LossReason[0].Name)
+}
+
+func TestLeads_List_Empty(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	got, err := leads{api: a}.List(context.Background(), LeadsFilter{})
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestLeads_List_InvalidFilter(t *testing.T) {
+	_, err := leads{}.List(context.Background(), LeadsFilter{Relations: []string{"example"}})
+	require.EqualError(t, err, "unexpected lead relation: example")
+
+	_, err = leads{}.List(context.Background(), LeadsFilter{Order: &Order{Field: "name", Direction: OrderAsc}})
+	require.EqualError(t, err, "unexpected order field: name")
+}
+
+func TestLeads_GetByID(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/leads/1":
+			assert.Equal(t, "catalog_elements", r.URL.Query().Get("with"))
+			_, _ = w.Write([]byte(`{"id": 1, "_embedded": {"catalog_elements": [{"id": 5, "metadata": {"quantity": 2, "catalog_id": 3}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	got, err := leads{api: a}.GetByID(context.Background(), 1, WithCatalogElements)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.Id)
+	require.Equal(t, &CatalogElementMetadata{Quantity: 2, CatalogId: 3}, got.Embedded.CatalogElements[0].Metadata)
+
+	_, err = leads{api: a}.GetByID(context.Background(), 2)
+	require.True(t, errors.Is(err, ErrNotFound), err)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type LinkMetadata struct {
+	IsMain    bool    `json:"is_main,omitempty"`    //Является ли привязанный контакт главным
+	UpdatedBy int     `json:"updated_by,omitempty"` //ID пользователя, от имени которого осуществляется привязка
+	CatalogID int     `json:"catalog_id,omitempty"` //ID списка привязанного элемента
+	Quantity  float64 `json:"quantity,omitempty"`   //Количество привязанных элементов списка
+	PriceID   int     `json:"price_id,omitempty"`   //ID поля типа Цена, которое будет установлено для привязанного элемента в контексте сущности
+}
+
+type Link struct {
+	EntityID     int           `json:"entity_id"`             //ID главной сущности
+	EntityType   EntityType    `json:"entity_type,omitempty"` //Тип главной сущности
+	ToEntityID   int           `json:"to_entity_id"`          //ID связанной сущности
+	ToEntityType EntityType    `json:"to_entity_type"`        //Тип связанной сущности (leads, contacts, companies, customers, catalog_elements)
+	Metadata     *LinkMetadata `json:"metadata,omitempty"`    //Метаданные связанной сущности
+}
+
+// LinksFilter describes parameters of Links list request.
+type LinksFilter struct {
+	EntityIDs    []int // only used by List, required there
+	ToEntityID   int
+	ToEntityType EntityType
+	ToCatalogID  int
+	Page         int
+	Limit        int // 250 at most
+}
+
+func (f LinksFilter) values() url.Values {
+	q := url.Values{}
+	addInts(q, "filter[entity_id]", f.EntityIDs)
+	if f.ToEntityID != 0 {
+		q.Set("filter[to_entity_id]", strconv.Itoa(f.ToEntityID))
+	}
+	if f.ToEntityType != "" {
+		q.Set("filter[to_entity_type]", string(f.ToEntityType))
+	}
+	if f.ToCatalogID != 0 {
+		q.Set("filter[to_catalog_id]", strconv.Itoa(f.ToCatalogID))
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q
+}
+
+// Links describes methods available for links of an entity to other entities.
+type Links interface {
+	List(ctx context.Context, filter LinksFilter) ([]Link, error)
+	Iterate(filter LinksFilter) *Iterator[Link]
+	ListByEntity(ctx context.Context, entityID int, filter LinksFilter) ([]Link, error)
+	Link(ctx context.Context, links []Link) ([]Link, error)
+	Unlink(ctx context.Context, links []Link) error
+}
+
+// Verify interface compliance.
+var _ Links = links{}
+
+type links struct {
+	api    *api
+	entity EntityType
+}
+
+func newLinks(api *api, entity EntityType) Links {
+	return links{api: api, entity: entity}
+}
+
+// List returns a single page of links of entities with filter.EntityIDs.
+func (a links) List(ctx context.Context, filter LinksFilter) ([]Link, error) {
+	items, _, err := listPage[Link](ctx, a.api, endpoint(a.entity)+"/links", filter.values(), "links")
+	return items, err
+}
+
+// Iterate walks all links of entities with filter.EntityIDs starting from
+// filter.Page.
+func (a links) Iterate(filter LinksFilter) *Iterator[Link] {
+	return iterate[Link](a.api, endpoint(a.entity)+"/links", "links", filter.Page, filter.values(), nil)
+}
+
+// ListByEntity returns a single page of links of a single entity.
+func (a links) ListByEntity(ctx context.Context, entityID int, filter LinksFilter) ([]Link, error) {
+	items, _, err := listPage[Link](ctx, a.api, endpoint(a.entity).id(entityID)+"/links", filter.values(), "links")
+	return items, err
+}
+
+// Link links entities identified by link.EntityID to the target entities.
+func (a links) Link(ctx context.Context, links []Link) ([]Link, error) {
+	resp, rErr := a.api.do(ctx, endpoint(a.entity)+"/link", http.MethodPost, nil, nil, links)
+	if rErr != nil {
+		return nil, fmt.Errorf("link %s: %w", a.entity, rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Links []Link `json:"links"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Links, nil
+}
+
+func (a links) Unlink(ctx context.Context, links []Link) error {
+	resp, rErr := a.api.do(ctx, endpoint(a.entity)+"/unlink", http.MethodPost, nil, nil, links)
+	if rErr != nil {
+		return fmt.Errorf("unlink %s: %w", a.entity, rErr)
+	}
+	if resp.StatusCode >= 400 {
+		return a.api.read(resp, nil)
+	}
+	discardBody(resp)
+
+	return nil
+}
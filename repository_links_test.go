@@ -0,0 +1,108 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks_Link(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v4/leads/link", r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"entity_id": 7, "to_entity_id": 10, "to_entity_type": "contacts", "metadata": {"is_main": true}},
+			{"entity_id": 7, "to_entity_id": 20, "to_entity_type": "catalog_elements", "metadata": {"catalog_id": 1, "quantity": 2.5}}
+		]`, string(body))
+
+		_, _ = w.Write([]byte(`{"_embedded": {"links": [
+			{"entity_id": 7, "entity_type": "leads", "to_entity_id": 10, "to_entity_type": "contacts", "metadata": {"is_main": true}},
+			{"entity_id": 7, "entity_type": "leads", "to_entity_id": 20, "to_entity_type": "catalog_elements", "metadata": {"catalog_id": 1, "quantity": 2.5}}
+		]}}`))
+	}))
+
+	items, err := links{api: a, entity: EntityLeads}.Link(context.Background(), []Link{
+		{EntityID: 7, ToEntityID: 10, ToEntityType: EntityContacts, Metadata: &LinkMetadata{IsMain: true}},
+		{EntityID: 7, ToEntityID: 20, ToEntityType: EntityCatalogElements, Metadata: &LinkMetadata{CatalogID: 1, Quantity: 2.5}},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, EntityLeads, items[0].EntityType)
+}
+
+func TestLinks_ListByEntity(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/contacts/10/links", r.URL.Path)
+		assert.Equal(t, "companies", r.URL.Query().Get("filter[to_entity_type]"))
+		_, _ = w.Write([]byte(`{"_embedded": {"links": [
+			{"entity_id": 10, "entity_type": "contacts", "to_entity_id": 3, "to_entity_type": "companies", "metadata": null}
+		]}}`))
+	}))
+
+	items, err := links{api: a, entity: EntityContacts}.ListByEntity(context.Background(), 10, LinksFilter{ToEntityType: EntityCompanies})
+	require.NoError(t, err)
+	require.Equal(t, []Link{{EntityID: 10, EntityType: EntityContacts, ToEntityID: 3, ToEntityType: EntityCompanies}}, items)
+}
+
+func TestLinks_Unlink(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads/unlink", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	require.NoError(t, links{api: a, entity: EntityLeads}.Unlink(context.Background(), []Link{
+		{EntityID: 7, ToEntityID: 10, ToEntityType: EntityContacts},
+	}))
+}
+
+func TestLinks_Iterate(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/leads/links" || r.URL.Query().Get("filter[entity_id][]") != "7" {
+			t.Errorf("unexpected request: %s", r.URL)
+			return
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "2":
+			_, _ = w.Write([]byte(`{"_links": {"next": {"href": ""}}, "_embedded": {"links": [{"entity_id": 7, "to_entity_id": 10, "to_entity_type": "contacts"}]}}`))
+		case "3":
+			_, _ = w.Write([]byte(`{"_embedded": {"links": [{"entity_id": 7, "to_entity_id": 20, "to_entity_type": "companies"}]}}`))
+		default:
+			t.Errorf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+
+	items, err := Collect(context.Background(), links{api: a, entity: EntityLeads}.Iterate(LinksFilter{EntityIDs: []int{7}, Page: 2}))
+	require.NoError(t, err)
+	require.Equal(t, []Link{
+		{EntityID: 7, ToEntityID: 10, ToEntityType: EntityContacts},
+		{EntityID: 7, ToEntityID: 20, ToEntityType: EntityCompanies},
+	}, items)
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NoteType is a type of an entity note.
+type NoteType string
+
+const (
+	NoteCommon                 NoteType = "common"
+	NoteCallIn                 NoteType = "call_in"
+	NoteCallOut                NoteType = "call_out"
+	NoteServiceMessage         NoteType = "service_message"
+	NoteMessageCashier         NoteType = "message_cashier"
+	NoteGeolocation            NoteType = "geolocation"
+	NoteSMSIn                  NoteType = "sms_in"
+	NoteSMSOut                 NoteType = "sms_out"
+	NoteExtendedServiceMessage NoteType = "extended_service_message"
+	NoteAttachment             NoteType = "attachment"
+)
+
+// Call statuses of call_in and call_out notes.
+const (
+	CallStatusLeftMessage  = 1 //Оставил сообщение
+	CallStatusCallLater    = 2 //Перезвонить позже
+	CallStatusNotAvailable = 3 //Нет на месте
+	CallStatusSuccess      = 4 //Разговор состоялся
+	CallStatusWrongNumber  = 5 //Неверный номер
+	CallStatusNoAnswer     = 6 //Не дозвонился
+	CallStatusBusy         = 7 //Номер занят
+)
+
+// Statuses of message_cashier notes.
+const (
+	CashierMessageCreated  = "created"
+	CashierMessageShown    = "shown"
+	CashierMessageCanceled = "canceled"
+)
+
+type Note struct {
+	ID                int             `json:"id,omitempty"`
+	EntityID          int             `json:"entity_id,omitempty"`           //ID родительской сущности примечания
+	CreatedBy         int             `json:"created_by,omitempty"`          //ID пользователя, создавшего примечание
+	UpdatedBy         int             `json:"updated_by,omitempty"`          //ID пользователя, изменившего примечание последним
+	CreatedAt         int             `json:"created_at,omitempty"`          //Дата создания примечания, передается в Unix Timestamp
+	UpdatedAt         int             `json:"updated_at,omitempty"`          //Дата изменения примечания, передается в Unix Timestamp
+	ResponsibleUserID int             `json:"responsible_user_id,omitempty"` //ID пользователя, ответственного за примечание
+	GroupID           int             `json:"group_id,omitempty"`            //ID группы, в которой состоит ответственный пользователь за примечание
+	NoteType          NoteType        `json:"note_type,omitempty"`           //Тип примечания
+	Params            json.RawMessage `json:"params,omitempty"`              //Свойства примечания, зависят от типа примечания
+	AccountID         int             `json:"account_id,omitempty"`          //ID аккаунта, в котором находится примечание
+	RequestID         string          `json:"request_id,omitempty"`          //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+// NoteParams is implemented by typed params of every note type.
+type NoteParams interface {
+	NoteType() NoteType
+}
+
+type CommonNoteParams struct {
+	Text string `json:"text"` //Текст примечания
+}
+
+type CallNoteParams struct {
+	UniqueID   string `json:"uniq"`                  //Уникальный идентификатор звонка
+	Duration   int    `json:"duration"`              //Длительность звонка в секундах
+	Source     string `json:"source"`                //Источник звонка
+	Link       string `json:"link,omitempty"`        //Ссылка на запись звонка
+	Phone      string `json:"phone"`                 //Номер телефона
+	CallResult string `json:"call_result,omitempty"` //Результат звонка
+	CallStatus int    `json:"call_status,omitempty"` //Статус звонка
+}
+
+type (
+	CallInNoteParams  CallNoteParams
+	CallOutNoteParams CallNoteParams
+)
+
+type ServiceMessageNoteParams struct {
+	Service string `json:"service"` //Название сервиса, от чьего имени создано примечание
+	Text    string `json:"text"`    //Текст примечания
+}
+
+type ExtendedServiceMessageNoteParams ServiceMessageNoteParams
+
+type MessageCashierNoteParams struct {
+	Status string `json:"status"` //Статус сообщения: created, shown или canceled
+	Text   string `json:"text"`   //Текст примечания
+}
+
+type GeolocationNoteParams struct {
+	Text      string `json:"text"`      //Текст примечания
+	Address   string `json:"address"`   //Адрес
+	Longitude string `json:"longitude"` //Долгота
+	Latitude  string `json:"latitude"`  //Широта
+}
+
+type SMSNoteParams struct {
+	Text  string `json:"text"`  //Текст сообщения
+	Phone string `json:"phone"` //Номер телефона
+}
+
+type (
+	SMSInNoteParams  SMSNoteParams
+	SMSOutNoteParams SMSNoteParams
+)
+
+type AttachmentNoteParams struct {
+	OriginalName string `json:"original_name"`          //Название файла
+	FileUUID     string `json:"file_uuid"`              //UUID файла в сервисе файлов
+	VersionUUID  string `json:"version_uuid,omitempty"` //UUID версии файла
+	FileName     string `json:"file_name,omitempty"`    //Имя файла в сервисе файлов
+}
+
+func (CommonNoteParams) NoteType() NoteType                 { return NoteCommon }
+func (CallInNoteParams) NoteType() NoteType                 { return NoteCallIn }
+func (CallOutNoteParams) NoteType() NoteType                { return NoteCallOut }
+func (ServiceMessageNoteParams) NoteType() NoteType         { return NoteServiceMessage }
+func (ExtendedServiceMessageNoteParams) NoteType() NoteType { return NoteExtendedServiceMessage }
+func (MessageCashierNoteParams) NoteType() NoteType         { return NoteMessageCashier }
+func (GeolocationNoteParams) NoteType() NoteType            { return NoteGeolocation }
+func (SMSInNoteParams) NoteType() NoteType                  { return NoteSMSIn }
+func (SMSOutNoteParams) NoteType() NoteType                 { return NoteSMSOut }
+func (AttachmentNoteParams) NoteType() NoteType             { return NoteAttachment }
+
+// NewNote returns a note of the params type attached to the entity.
+func NewNote(entityID int, params NoteParams) (Note, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return Note{}, err
+	}
+
+	return Note{EntityID: entityID, NoteType: params.NoteType(), Params: data}, nil
+}
+
+// TypedParams decodes note params into the type matching note.NoteType.
+func (n Note) TypedParams() (NoteParams, error) {
+	var params NoteParams
+	switch n.NoteType {
+	case NoteCommon:
+		params = &CommonNoteParams{}
+	case NoteCallIn:
+		params = &CallInNoteParams{}
+	case NoteCallOut:
+		params = &CallOutNoteParams{}
+	case NoteServiceMessage:
+		params = &ServiceMessageNoteParams{}
+	case NoteExtendedServiceMessage:
+		params = &ExtendedServiceMessageNoteParams{}
+	case NoteMessageCashier:
+		params = &MessageCashierNoteParams{}
+	case NoteGeolocation:
+		params = &GeolocationNoteParams{}
+	case NoteSMSIn:
+		params = &SMSInNoteParams{}
+	case NoteSMSOut:
+		params = &SMSOutNoteParams{}
+	case NoteAttachment:
+		params = &AttachmentNoteParams{}
+	default:
+		return nil, fmt.Errorf("unexpected note type: %s", n.NoteType)
+	}
+
+	if err := n.DecodeParams(params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// DecodeParams unmarshals raw note params into v.
+func (n Note) DecodeParams(v interface{}) error {
+	if len(n.Params) == 0 {
+		return errEmptyValue
+	}
+
+	return json.Unmarshal(n.Params, v)
+}
+
+// NotesFilter describes parameters of Notes list request.
+type NotesFilter struct {
+	IDs       []int
+	EntityIDs []int
+	NoteTypes []NoteType
+	UpdatedAt *Range
+	Order     *Order // by updated_at or id
+	Page      int
+	Limit     int // 250 at most
+}
+
+func (f NotesFilter) values() (url.Values, error) {
+	q := url.Values{}
+	addInts(q, "filter[id]", f.IDs)
+	addInts(q, "filter[entity_id]", f.EntityIDs)
+	for _, noteType := range f.NoteTypes {
+		q.Add("filter[note_type][]", string(noteType))
+	}
+	addRange(q, "filter[updated_at]", f.UpdatedAt)
+	if err := addOrder(q, f.Order, "updated_at", "id"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+// Notes describes methods available for notes of an entity.
+type Notes interface {
+	List(ctx context.Context, filter NotesFilter) ([]Note, error)
+	Iterate(filter NotesFilter) *Iterator[Note]
+	GetByID(ctx context.Context, id int) (*Note, error)
+	Create(ctx context.Context, notes []Note) ([]Note, error)
+	Update(ctx context.Context, notes []Note) ([]Note, error)
+}
+
+// Verify interface compliance.
+var _ Notes = notes{}
+
+type notes struct {
+	api    *api
+	entity EntityType
+}
+
+func newNotes(api *api, entity EntityType) Notes {
+	return notes{api: api, entity: entity}
+}
+
+func (a notes) endpoint() endpoint {
+	return endpoint(string(a.entity) + "/notes")
+}
+
+func (a notes) List(ctx context.Context, filter NotesFilter) ([]Note, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Note](ctx, a.api, a.endpoint(), query, "notes")
+	return items, err
+}
+
+// Iterate walks all notes matching the filter starting from filter.Page.
+func (a notes) Iterate(filter NotesFilter) *Iterator[Note] {
+	query, err := filter.values()
+	return iterate[Note](a.api, a.endpoint(), "notes", filter.Page, query, err)
+}
+
+func (a notes) GetByID(ctx context.Context, id int) (*Note, error) {
+	note := &Note{}
+	if err := a.api.get(ctx, a.endpoint().id(id), nil, note); err != nil {
+		return nil, fmt.Errorf("get note %d: %w", id, err)
+	}
+
+	return note, nil
+}
+
+// Create adds notes to the entities identified by note.EntityID.
+func (a notes) Create(ctx context.Context, notes []Note) ([]Note, error) {
+	return a.save(ctx, http.MethodPost, notes)
+}
+
+func (a notes) Update(ctx context.Context, notes []Note) ([]Note, error) {
+	return a.save(ctx, http.MethodPatch, notes)
+}
+
+func (a notes) save(ctx context.Context, method string, notes []Note) ([]Note, error) {
+	resp, rErr := a.api.do(ctx, a.endpoint(), method, nil, nil, notes)
+	if rErr != nil {
+		return nil, fmt.Errorf("save notes: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Notes []Note `json:"notes"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Notes, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotes_Create(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v4/contacts/notes", r.URL.Path)
+
+		var body []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []map[string]interface{}{{
+			"entity_id": float64(7),
+			"note_type": "call_in",
+			"params": map[string]interface{}{
+				"uniq":        "8f52d38a-5fb3-406d-93a3-a4832dc28f8b",
+				"duration":    float64(60),
+				"source":      "onlinePBX",
+				"link":        "https://example.com/call.mp3",
+				"phone":       "+79999999999",
+				"call_status": float64(CallStatusSuccess),
+			},
+		}}, body)
+
+		_, _ = w.Write([]byte(`{"_embedded": {"notes": [{"id": 42, "entity_id": 7}]}}`))
+	}))
+
+	note, err := NewNote(7, CallInNoteParams{
+		UniqueID:   "8f52d38a-5fb3-406d-93a3-a4832dc28f8b",
+		Duration:   60,
+		Source:     "onlinePBX",
+		Link:       "https://example.com/call.mp3",
+		Phone:      "+79999999999",
+		CallStatus: CallStatusSuccess,
+	})
+	require.NoError(t, err)
+
+	items, err := notes{api: a, entity: EntityContacts}.Create(context.Background(), []Note{note})
+	require.NoError(t, err)
+	require.Equal(t, []Note{{ID: 42, EntityID: 7}}, items)
+}
+
+func TestNotes_List(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads/notes", r.URL.Path)
+		assert.Equal(t, []string{"common", "sms_out"}, r.URL.Query()["filter[note_type][]"])
+		_, _ = w.Write([]byte(`{"_embedded": {"notes": [
+			{"id": 1, "note_type": "common", "params": {"text": "Текст"}},
+			{"id": 2, "note_type": "sms_out", "params": {"text": "Код 1234", "phone": "+79999999999"}}
+		]}}`))
+	}))
+
+	items, err := notes{api: a, entity: EntityLeads}.List(context.Background(), NotesFilter{
+		NoteTypes: []NoteType{NoteCommon, NoteSMSOut},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	params, err := items[0].TypedParams()
+	require.NoError(t, err)
+	require.Equal(t, &CommonNoteParams{Text: "Текст"}, params)
+
+	params, err = items[1].TypedParams()
+	require.NoError(t, err)
+	require.Equal(t, &SMSOutNoteParams{Text: "Код 1234", Phone: "+79999999999"}, params)
+}
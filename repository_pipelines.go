@@ -0,0 +1,253 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	pipelinesEndpoint endpoint = "leads/pipelines"
+)
+
+// System statuses present in every pipeline.
+const (
+	StatusWon  = 142 //Успешно реализовано
+	StatusLost = 143 //Закрыто и не реализовано
+)
+
+// Status types.
+const (
+	StatusTypeRegular  = 0
+	StatusTypeUnsorted = 1
+)
+
+// WithDescriptions adds descriptions to statuses.
+const WithDescriptions = "descriptions"
+
+type StatusDescription struct {
+	ID          int    `json:"id,omitempty"`
+	AccountID   int    `json:"account_id,omitempty"`
+	Description string `json:"description"`          //Описание статуса
+	Level       string `json:"level"`                //Уровень пользователя, для которого показывается описание (newbie, candidate, master)
+	CreatedAt   int    `json:"created_at,omitempty"` //Дата создания описания, передается в Unix Timestamp
+	UpdatedAt   int    `json:"updated_at,omitempty"` //Дата изменения описания, передается в Unix Timestamp
+	CreatedBy   int    `json:"created_by,omitempty"` //ID пользователя, создавшего описание
+	UpdatedBy   int    `json:"updated_by,omitempty"` //ID пользователя, изменившего описание
+}
+
+type Status struct {
+	ID           int                 `json:"id,omitempty"`
+	Name         string              `json:"name,omitempty"`         //Название статуса
+	Sort         int                 `json:"sort,omitempty"`         //Сортировка статуса
+	IsEditable   bool                `json:"is_editable,omitempty"`  //Доступен ли статус для редактирования
+	PipelineID   int                 `json:"pipeline_id,omitempty"`  //ID воронки, в которой находится статус
+	Color        string              `json:"color,omitempty"`        //Цвет статуса
+	Type         int                 `json:"type,omitempty"`         //Тип статуса: 0 – обычный, 1 – неразобранное
+	AccountID    int                 `json:"account_id,omitempty"`   //ID аккаунта, в котором находится воронка
+	Descriptions []StatusDescription `json:"descriptions,omitempty"` //Описания статуса. Возвращаются при запросе с descriptions
+}
+
+type PipelineEmbedded struct {
+	Statuses []Status `json:"statuses,omitempty"`
+}
+
+type Pipeline struct {
+	ID           int               `json:"id,omitempty"`
+	Name         string            `json:"name,omitempty"`           //Название воронки
+	Sort         int               `json:"sort,omitempty"`           //Сортировка воронки
+	IsMain       bool              `json:"is_main,omitempty"`        //Является ли воронка главной
+	IsUnsortedOn bool              `json:"is_unsorted_on,omitempty"` //Включено ли неразобранное в воронке
+	IsArchive    bool              `json:"is_archive,omitempty"`     //Является ли воронка архивной
+	AccountID    int               `json:"account_id,omitempty"`     //ID аккаунта, в котором находится воронка
+	Embedded     *PipelineEmbedded `json:"_embedded,omitempty"`      //Статусы воронки. При создании воронки необходимо передать хотя бы один статус
+}
+
+// Pipelines describes methods available for leads pipelines and their statuses.
+type Pipelines interface {
+	List(ctx context.Context) ([]Pipeline, error)
+	GetByID(ctx context.Context, id int) (*Pipeline, error)
+	Create(ctx context.Context, pipelines []Pipeline) ([]Pipeline, error)
+	Update(ctx context.Context, pipeline Pipeline) (*Pipeline, error)
+	Delete(ctx context.Context, id int) error
+	ListStatuses(ctx context.Context, pipelineID int, with ...string) ([]Status, error)
+	GetStatus(ctx context.Context, pipelineID, statusID int, with ...string) (*Status, error)
+	CreateStatuses(ctx context.Context, pipelineID int, statuses []Status) ([]Status, error)
+	UpdateStatus(ctx context.Context, pipelineID int, status Status) (*Status, error)
+	DeleteStatus(ctx context.Context, pipelineID, statusID int) error
+	ResolveStatus(ctx context.Context, pipelineName, statusName string) (*Status, error)
+}
+
+// Verify interface compliance.
+var _ Pipelines = pipelines{}
+
+type pipelines struct {
+	api *api
+}
+
+func newPipelines(api *api) Pipelines {
+	return pipelines{api: api}
+}
+
+func statusesEndpoint(pipelineID int) endpoint {
+	return pipelinesEndpoint.id(pipelineID) + "/statuses"
+}
+
+// List returns all pipelines of the account. amoCRM does not split them
+// into pages.
+func (a pipelines) List(ctx context.Context) ([]Pipeline, error) {
+	items, _, err := listPage[Pipeline](ctx, a.api, pipelinesEndpoint, nil, "pipelines")
+	return items, err
+}
+
+func (a pipelines) GetByID(ctx context.Context, id int) (*Pipeline, error) {
+	pipeline := &Pipeline{}
+	if err := a.api.get(ctx, pipelinesEndpoint.id(id), nil, pipeline); err != nil {
+		return nil, fmt.Errorf("get pipeline %d: %w", id, err)
+	}
+
+	return pipeline, nil
+}
+
+func (a pipelines) Create(ctx context.Context, pipelines []Pipeline) ([]Pipeline, error) {
+	resp, rErr := a.api.do(ctx, pipelinesEndpoint, http.MethodPost, nil, nil, pipelines)
+	if rErr != nil {
+		return nil, fmt.Errorf("create pipelines: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Pipelines []Pipeline `json:"pipelines"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Pipelines, nil
+}
+
+func (a pipelines) Update(ctx context.Context, pipeline Pipeline) (*Pipeline, error) {
+	resp, rErr := a.api.do(ctx, pipelinesEndpoint.id(pipeline.ID), http.MethodPatch, nil, nil, pipeline)
+	if rErr != nil {
+		return nil, fmt.Errorf("update pipeline: %w", rErr)
+	}
+
+	res := &Pipeline{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a pipelines) Delete(ctx context.Context, id int) error {
+	return a.api.delete(ctx, pipelinesEndpoint.id(id))
+}
+
+// ListStatuses returns all statuses of the pipeline. amoCRM does not split
+// them into pages.
+func (a pipelines) ListStatuses(ctx context.Context, pipelineID int, with ...string) ([]Status, error) {
+	query := url.Values{}
+	if err := addRelations(query, "status", with, WithDescriptions); err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Status](ctx, a.api, statusesEndpoint(pipelineID), query, "statuses")
+	return items, err
+}
+
+func (a pipelines) GetStatus(ctx context.Context, pipelineID, statusID int, with ...string) (*Status, error) {
+	query := url.Values{}
+	if err := addRelations(query, "status", with, WithDescriptions); err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+	if err := a.api.get(ctx, statusesEndpoint(pipelineID).id(statusID), query, status); err != nil {
+		return nil, fmt.Errorf("get status %d: %w", statusID, err)
+	}
+
+	return status, nil
+}
+
+func (a pipelines) CreateStatuses(ctx context.Context, pipelineID int, statuses []Status) ([]Status, error) {
+	resp, rErr := a.api.do(ctx, statusesEndpoint(pipelineID), http.MethodPost, nil, nil, statuses)
+	if rErr != nil {
+		return nil, fmt.Errorf("create statuses: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Statuses []Status `json:"statuses"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Statuses, nil
+}
+
+func (a pipelines) UpdateStatus(ctx context.Context, pipelineID int, status Status) (*Status, error) {
+	resp, rErr := a.api.do(ctx, statusesEndpoint(pipelineID).id(status.ID), http.MethodPatch, nil, nil, status)
+	if rErr != nil {
+		return nil, fmt.Errorf("update status: %w", rErr)
+	}
+
+	res := &Status{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a pipelines) DeleteStatus(ctx context.Context, pipelineID, statusID int) error {
+	return a.api.delete(ctx, statusesEndpoint(pipelineID).id(statusID))
+}
+
+// ResolveStatus finds a status by pipeline and status names ignoring case,
+// so moving a lead through stages does not need hard-coded IDs.
+func (a pipelines) ResolveStatus(ctx context.Context, pipelineName, statusName string) (*Status, error) {
+	pipelines, err := a.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pipeline := range pipelines {
+		if !strings.EqualFold(pipeline.Name, pipelineName) || pipeline.Embedded == nil {
+			continue
+		}
+		for _, status := range pipeline.Embedded.Statuses {
+			if strings.EqualFold(status.Name, statusName) {
+				status.PipelineID = pipeline.ID
+				return &status, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("status %q of pipeline %q: %w", statusName, pipelineName, ErrNotFound)
+}
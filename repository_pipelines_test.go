@@ -0,0 +1,69 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelines_ResolveStatus(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads/pipelines", r.URL.Path)
+		_, _ = w.Write([]byte(`{"_embedded": {"pipelines": [
+			{"id": 1, "name": "Воронка", "is_main": true, "_embedded": {"statuses": [
+				{"id": 10, "name": "Первичный контакт"},
+				{"id": 142, "name": "Успешно реализовано"}
+			]}},
+			{"id": 2, "name": "Партнёры", "_embedded": {"statuses": [
+				{"id": 41138881, "name": "Первичный контакт"}
+			]}}
+		]}}`))
+	}))
+
+	status, err := pipelines{api: a}.ResolveStatus(context.Background(), "партнёры", "первичный контакт")
+	require.NoError(t, err)
+	require.Equal(t, &Status{ID: 41138881, Name: "Первичный контакт", PipelineID: 2}, status)
+
+	_, err = pipelines{api: a}.ResolveStatus(context.Background(), "Воронка", "Переговоры")
+	require.True(t, errors.Is(err, ErrNotFound), err)
+}
+
+func TestPipelines_ListStatuses(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads/pipelines/1/statuses", r.URL.Path)
+		assert.Equal(t, WithDescriptions, r.URL.Query().Get("with"))
+		_, _ = w.Write([]byte(`{"_embedded": {"statuses": [
+			{"id": 143, "name": "Закрыто и не реализовано", "pipeline_id": 1, "descriptions": [{"id": 5, "level": "newbie", "description": "Отказ"}]}
+		]}}`))
+	}))
+
+	statuses, err := pipelines{api: a}.ListStatuses(context.Background(), 1, WithDescriptions)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, StatusLost, statuses[0].ID)
+	require.Equal(t, "Отказ", statuses[0].Descriptions[0].Description)
+}
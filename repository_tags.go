@@ -0,0 +1,225 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Tag struct {
+	ID    int    `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`  //Название тега
+	Color string `json:"color,omitempty"` //Цвет тега, доступен только для сделок
+}
+
+// TagsFilter describes parameters of Tags list request.
+type TagsFilter struct {
+	Query string
+	Name  string
+	IDs   []int
+	Page  int
+	Limit int // 250 at most
+}
+
+func (f TagsFilter) values() url.Values {
+	q := url.Values{}
+	if f.Query != "" {
+		q.Set("query", f.Query)
+	}
+	if f.Name != "" {
+		q.Set("filter[name]", f.Name)
+	}
+	addInts(q, "filter[id]", f.IDs)
+	addPage(q, f.Page, f.Limit)
+
+	return q
+}
+
+// Tags describes methods available for tags of an entity.
+type Tags interface {
+	List(ctx context.Context, filter TagsFilter) ([]Tag, error)
+	Iterate(filter TagsFilter) *Iterator[Tag]
+	Create(ctx context.Context, tags []Tag) ([]Tag, error)
+	Delete(ctx context.Context, ids ...int) error
+	AddTo(ctx context.Context, entityID int, tags ...Tag) ([]Tag, error)
+	RemoveFrom(ctx context.Context, entityID int, names ...string) ([]Tag, error)
+}
+
+// Verify interface compliance.
+var _ Tags = tags{}
+
+type tags struct {
+	api    *api
+	entity EntityType
+}
+
+func newTags(api *api, entity EntityType) Tags {
+	return tags{api: api, entity: entity}
+}
+
+func (a tags) endpoint() endpoint {
+	return endpoint(string(a.entity) + "/tags")
+}
+
+func (a tags) List(ctx context.Context, filter TagsFilter) ([]Tag, error) {
+	items, _, err := listPage[Tag](ctx, a.api, a.endpoint(), filter.values(), "tags")
+	return items, err
+}
+
+// Iterate walks all tags matching the filter starting from filter.Page.
+func (a tags) Iterate(filter TagsFilter) *Iterator[Tag] {
+	return iterate[Tag](a.api, a.endpoint(), "tags", filter.Page, filter.values(), nil)
+}
+
+func (a tags) Create(ctx context.Context, tags []Tag) ([]Tag, error) {
+	resp, rErr := a.api.do(ctx, a.endpoint(), http.MethodPost, nil, nil, tags)
+	if rErr != nil {
+		return nil, fmt.Errorf("create tags: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Tags []Tag `json:"tags"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Tags, nil
+}
+
+func (a tags) Delete(ctx context.Context, ids ...int) error {
+	data := make([]Tag, 0, len(ids))
+	for _, id := range ids {
+		data = append(data, Tag{ID: id})
+	}
+
+	resp, rErr := a.api.do(ctx, a.endpoint(), http.MethodDelete, nil, nil, data)
+	if rErr != nil {
+		return fmt.Errorf("delete tags: %w", rErr)
+	}
+	if resp.StatusCode >= 400 {
+		return a.api.read(resp, nil)
+	}
+	discardBody(resp)
+
+	return nil
+}
+
+// AddTo adds tags to the entity keeping the ones it already has.
+// Tags are matched by ID or by name ignoring case.
+func (a tags) AddTo(ctx context.Context, entityID int, tags ...Tag) ([]Tag, error) {
+	current, err := a.entityTags(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if indexTag(current, tag) < 0 {
+			current = append(current, tag)
+		}
+	}
+
+	return a.setEntityTags(ctx, entityID, current)
+}
+
+// RemoveFrom removes tags with given names from the entity keeping the other ones.
+func (a tags) RemoveFrom(ctx context.Context, entityID int, names ...string) ([]Tag, error) {
+	current, err := a.entityTags(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]Tag, 0, len(current))
+	for _, tag := range current {
+		if !containsFold(names, tag.Name) {
+			kept = append(kept, tag)
+		}
+	}
+
+	return a.setEntityTags(ctx, entityID, kept)
+}
+
+type entityTags struct {
+	Embedded struct {
+		Tags []Tag `json:"tags"`
+	} `json:"_embedded"`
+}
+
+func (a tags) entityTags(ctx context.Context, entityID int) ([]Tag, error) {
+	var res entityTags
+	if err := a.api.get(ctx, endpoint(a.entity).id(entityID), nil, &res); err != nil {
+		return nil, fmt.Errorf("get %s %d tags: %w", a.entity, entityID, err)
+	}
+
+	return res.Embedded.Tags, nil
+}
+
+func (a tags) setEntityTags(ctx context.Context, entityID int, tags []Tag) ([]Tag, error) {
+	var data entityTags
+	// An empty list must be sent as is to remove all tags.
+	data.Embedded.Tags = make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		if tag.ID != 0 {
+			data.Embedded.Tags = append(data.Embedded.Tags, Tag{ID: tag.ID})
+		} else {
+			data.Embedded.Tags = append(data.Embedded.Tags, Tag{Name: tag.Name})
+		}
+	}
+
+	resp, rErr := a.api.do(ctx, endpoint(a.entity).id(entityID), http.MethodPatch, nil, nil, data)
+	if rErr != nil {
+		return nil, fmt.Errorf("update %s %d tags: %w", a.entity, entityID, rErr)
+	}
+	var res struct {
+		ID int `json:"id"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func indexTag(tags []Tag, tag Tag) int {
+	for i, t := range tags {
+		if tag.ID != 0 && t.ID == tag.ID || tag.Name != "" && strings.EqualFold(t.Name, tag.Name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags_AddTo(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads/7", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": 7, "_embedded": {"tags": [
+				{"id": 1, "name": "partner_reg", "color": null},
+				{"id": 2, "name": "vip", "color": "DDEBB5"}
+			]}}`))
+		case http.MethodPatch:
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"_embedded": {"tags": [{"id": 1}, {"id": 2}, {"name": "new"}]}}`, string(body))
+			_, _ = w.Write([]byte(`{"id": 7, "updated_at": 1600000000}`))
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+
+	items, err := tags{api: a, entity: EntityLeads}.AddTo(context.Background(), 7, Tag{Name: "VIP"}, Tag{Name: "new"})
+	require.NoError(t, err)
+	require.Equal(t, []Tag{{ID: 1, Name: "partner_reg"}, {ID: 2, Name: "vip", Color: "DDEBB5"}, {Name: "new"}}, items)
+}
+
+func TestTags_RemoveFrom(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/contacts/7", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": 7, "_embedded": {"tags": [{"id": 1, "name": "partner_reg"}]}}`))
+		case http.MethodPatch:
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"_embedded": {"tags": []}}`, string(body))
+			_, _ = w.Write([]byte(`{"id": 7, "updated_at": 1600000000}`))
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+
+	items, err := tags{api: a, entity: EntityContacts}.RemoveFrom(context.Background(), 7, "Partner_Reg")
+	require.NoError(t, err)
+	require.Empty(t, items)
+}
+
+func TestTags_Delete(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/v4/companies/tags", r.URL.Path)
+
+		var body []Tag
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []Tag{{ID: 1}, {ID: 2}}, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	require.NoError(t, tags{api: a, entity: EntityCompanies}.Delete(context.Background(), 1, 2))
+}
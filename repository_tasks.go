@@ -0,0 +1,216 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	tasksEndpoint endpoint = "tasks"
+)
+
+// System task types.
+const (
+	TaskTypeCall    = 1 //Связаться
+	TaskTypeMeeting = 2 //Встреча
+)
+
+type TaskResult struct {
+	Text string `json:"text"` //Текст результата выполнения задачи
+}
+
+type Task struct {
+	ID                int         `json:"id,omitempty"`
+	CreatedBy         int         `json:"created_by,omitempty"`          //ID пользователя, создавшего задачу
+	UpdatedBy         int         `json:"updated_by,omitempty"`          //ID пользователя, изменившего задачу
+	CreatedAt         int         `json:"created_at,omitempty"`          //Дата создания задачи, передается в Unix Timestamp
+	UpdatedAt         int         `json:"updated_at,omitempty"`          //Дата изменения задачи, передается в Unix Timestamp
+	ResponsibleUserID int         `json:"responsible_user_id,omitempty"` //ID пользователя, ответственного за задачу
+	GroupID           int         `json:"group_id,omitempty"`            //ID группы, в которой состоит ответственный пользователь за задачу
+	EntityID          int         `json:"entity_id,omitempty"`           //ID сущности, к которой привязана задача
+	EntityType        EntityType  `json:"entity_type,omitempty"`         //Тип сущности, к которой привязана задача
+	IsCompleted       *bool       `json:"is_completed,omitempty"`        //Выполнена ли задача
+	TaskTypeID        int         `json:"task_type_id,omitempty"`        //Тип задачи
+	Text              string      `json:"text,omitempty"`                //Описание задачи
+	Duration          int         `json:"duration,omitempty"`            //Длительность задачи в секундах
+	CompleteTill      int         `json:"complete_till,omitempty"`       //Дата, когда задача должна быть завершена, передается в Unix Timestamp
+	Result            *TaskResult `json:"result,omitempty"`              //Результат выполнения задачи
+	AccountID         int         `json:"account_id,omitempty"`          //ID аккаунта, в котором находится задача
+	RequestID         string      `json:"request_id,omitempty"`          //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+// TasksFilter describes parameters of Tasks list request.
+type TasksFilter struct {
+	IDs                []int
+	ResponsibleUserIDs []int
+	IsCompleted        *bool
+	TaskTypeIDs        []int
+	EntityType         EntityType
+	EntityIDs          []int
+	UpdatedAt          *Range
+	Order              *Order // by created_at, complete_till or id
+	Page               int
+	Limit              int // 250 at most
+}
+
+func (f TasksFilter) values() (url.Values, error) {
+	q := url.Values{}
+	addInts(q, "filter[id]", f.IDs)
+	addInts(q, "filter[responsible_user_id]", f.ResponsibleUserIDs)
+	if f.IsCompleted != nil {
+		if *f.IsCompleted {
+			q.Set("filter[is_completed]", "1")
+		} else {
+			q.Set("filter[is_completed]", "0")
+		}
+	}
+	addInts(q, "filter[task_type]", f.TaskTypeIDs)
+	if f.EntityType != "" {
+		q.Set("filter[entity_type]", string(f.EntityType))
+	}
+	addInts(q, "filter[entity_id]", f.EntityIDs)
+	addRange(q, "filter[updated_at]", f.UpdatedAt)
+	if err := addOrder(q, f.Order, "created_at", "complete_till", "id"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+// Tasks describes methods available for Tasks entity.
+type Tasks interface {
+	List(ctx context.Context, filter TasksFilter) ([]Task, error)
+	Iterate(filter TasksFilter) *Iterator[Task]
+	GetByID(ctx context.Context, id int) (*Task, error)
+	Create(ctx context.Context, tasks []Task) ([]Task, error)
+	Update(ctx context.Context, tasks []Task) ([]Task, error)
+	Complete(ctx context.Context, id int, resultText string) (*Task, error)
+}
+
+// Verify interface compliance.
+var _ Tasks = tasks{}
+
+type tasks struct {
+	api *api
+}
+
+func newTasks(api *api) Tasks {
+	return tasks{api: api}
+}
+
+func (a tasks) List(ctx context.Context, filter TasksFilter) ([]Task, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Task](ctx, a.api, tasksEndpoint, query, "tasks")
+	return items, err
+}
+
+// Iterate walks all tasks matching the filter starting from filter.Page.
+func (a tasks) Iterate(filter TasksFilter) *Iterator[Task] {
+	query, err := filter.values()
+	return iterate[Task](a.api, tasksEndpoint, "tasks", filter.Page, query, err)
+}
+
+func (a tasks) GetByID(ctx context.Context, id int) (*Task, error) {
+	task := &Task{}
+	if err := a.api.get(ctx, tasksEndpoint.id(id), nil, task); err != nil {
+		return nil, fmt.Errorf("get task %d: %w", id, err)
+	}
+
+	return task, nil
+}
+
+func (a tasks) Create(ctx context.Context, tasks []Task) ([]Task, error) {
+	return a.save(ctx, http.MethodPost, tasks)
+}
+
+func (a tasks) Update(ctx context.Context, tasks []Task) ([]Task, error) {
+	return a.save(ctx, http.MethodPatch, tasks)
+}
+
+func (a tasks) save(ctx context.Context, method string, tasks []Task) ([]Task, error) {
+	resp, rErr := a.api.do(ctx, tasksEndpoint, method, nil, nil, tasks)
+	if rErr != nil {
+		return nil, fmt.Errorf("save tasks: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Tasks []Task `json:"tasks"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Tasks, nil
+}
+
+// Complete marks the task as completed with the given result.
+func (a tasks) Complete(ctx context.Context, id int, resultText string) (*Task, error) {
+	completed := true
+	task := Task{IsCompleted: &completed, Result: &TaskResult{Text: resultText}}
+
+	resp, rErr := a.api.do(ctx, tasksEndpoint.id(id), http.MethodPatch, nil, nil, task)
+	if rErr != nil {
+		return nil, fmt.Errorf("complete task: %w", rErr)
+	}
+
+	res := &Task{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// TaskTypeByCode returns the task type with given code, e.g. FOLLOW_UP.
+// The account must be fetched with WithTaskTypes relation.
+func (a *Account) TaskTypeByCode(code string) (TaskType, bool) {
+	for _, taskType := range a.Embedded.TaskTypes {
+		if taskType.Code == code {
+			return taskType, true
+		}
+	}
+
+	return TaskType{}, false
+}
+
+// TaskTypeByName returns the task type with given name ignoring case.
+// The account must be fetched with WithTaskTypes relation.
+func (a *Account) TaskTypeByName(name string) (TaskType, bool) {
+	for _, taskType := range a.Embedded.TaskTypes {
+		if strings.EqualFold(taskType.Name, name) {
+			return taskType, true
+		}
+	}
+
+	return TaskType{}, false
+}
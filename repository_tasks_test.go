@@ -0,0 +1,110 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTasks_List(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/tasks", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "0", q.Get("filter[is_completed]"))
+		assert.Equal(t, "leads", q.Get("filter[entity_type]"))
+		assert.Equal(t, []string{"7"}, q["filter[entity_id][]"])
+		assert.Equal(t, []string{"1", "2"}, q["filter[task_type][]"])
+		_, _ = w.Write([]byte(`{"_embedded": {"tasks": [
+			{"id": 3, "entity_id": 7, "entity_type": "leads", "task_type_id": 1, "text": "Позвонить", "complete_till": 1600000000}
+		]}}`))
+	}))
+
+	completed := false
+	items, err := tasks{api: a}.List(context.Background(), TasksFilter{
+		IsCompleted: &completed,
+		EntityType:  EntityLeads,
+		EntityIDs:   []int{7},
+		TaskTypeIDs: []int{TaskTypeCall, TaskTypeMeeting},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Task{{ID: 3, EntityID: 7, EntityType: EntityLeads, TaskTypeID: TaskTypeCall, Text: "Позвонить", CompleteTill: 1600000000}}, items)
+}
+
+func TestTasks_Complete(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v4/tasks/3", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"is_completed": true,
+			"result":       map[string]interface{}{"text": "Дозвонились"},
+		}, body)
+
+		_, _ = w.Write([]byte(`{"id": 3, "updated_at": 1600000001}`))
+	}))
+
+	task, err := tasks{api: a}.Complete(context.Background(), 3, "Дозвонились")
+	require.NoError(t, err)
+	require.Equal(t, 3, task.ID)
+}
+
+func TestAccount_TaskTypeByCode(t *testing.T) {
+	var account Account
+	require.NoError(t, json.Unmarshal([]byte(`{"_embedded": {"task_types": [
+		{"id": 1, "name": "Связаться", "code": "FOLLOW_UP"},
+		{"id": 2, "name": "Встреча", "code": "MEETING"},
+		{"id": 2243, "name": "Отправить КП", "code": null}
+	]}}`), &account))
+
+	taskType, ok := account.TaskTypeByCode("MEETING")
+	require.True(t, ok)
+	require.Equal(t, TaskTypeMeeting, taskType.ID)
+
+	taskType, ok = account.TaskTypeByName("отправить кп")
+	require.True(t, ok)
+	require.Equal(t, 2243, taskType.ID)
+
+	_, ok = account.TaskTypeByCode("CALL")
+	require.False(t, ok)
+}
+
+func TestTasks_Update_Reopen(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `[{"id":3,"is_completed":false}]` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		_, _ = w.Write([]byte(`{"_embedded": {"tasks": [{"id": 3}]}}`))
+	}))
+
+	reopened := false
+	_, err := tasks{api: a}.Update(context.Background(), []Task{{ID: 3, IsCompleted: &reopened}})
+	require.NoError(t, err)
+}
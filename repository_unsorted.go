@@ -0,0 +1,334 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	unsortedEndpoint endpoint = "leads/unsorted"
+)
+
+// UnsortedCategory is a source category of an incoming lead.
+type UnsortedCategory string
+
+const (
+	UnsortedForms UnsortedCategory = "forms"
+	UnsortedSIP   UnsortedCategory = "sip"
+	UnsortedChats UnsortedCategory = "chats"
+	UnsortedMail  UnsortedCategory = "mail"
+)
+
+type UnsortedEmbedded struct {
+	Leads     []Lead    `json:"leads,omitempty"`
+	Contacts  []Contact `json:"contacts,omitempty"`
+	Companies []Company `json:"companies,omitempty"`
+}
+
+type Unsorted struct {
+	UID        string            `json:"uid,omitempty"`         //UID неразобранного
+	SourceUID  string            `json:"source_uid,omitempty"`  //Идентификатор источника заявки
+	SourceName string            `json:"source_name,omitempty"` //Название источника заявки
+	Category   UnsortedCategory  `json:"category,omitempty"`    //Категория неразобранного (forms, sip, chats, mail)
+	PipelineID int               `json:"pipeline_id,omitempty"` //ID воронки, в которую будет добавлено неразобранное
+	CreatedAt  int               `json:"created_at,omitempty"`  //Дата создания неразобранного, передается в Unix Timestamp
+	Metadata   json.RawMessage   `json:"metadata,omitempty"`    //Метаданные заявки, зависят от категории
+	AccountID  int               `json:"account_id,omitempty"`  //ID аккаунта, в котором находится неразобранное
+	Embedded   *UnsortedEmbedded `json:"_embedded,omitempty"`   //Сделка, контакты и компании неразобранного
+	RequestID  string            `json:"request_id,omitempty"`  //Поле, которое вернется вам в ответе без изменений и не будет сохранено
+}
+
+// UnsortedMetadata is implemented by typed metadata of every category.
+type UnsortedMetadata interface {
+	Category() UnsortedCategory
+}
+
+type FormsMetadata struct {
+	FormID     string `json:"form_id"`               //Идентификатор формы на стороне интеграции
+	FormName   string `json:"form_name"`             //Название формы
+	FormPage   string `json:"form_page"`             //Страница, на которой установлена форма
+	IP         string `json:"ip,omitempty"`          //IP адрес, с которого поступила заявка
+	FormSentAt int    `json:"form_sent_at"`          //Дата отправки данных, передается в Unix Timestamp
+	Referer    string `json:"referer,omitempty"`     //Откуда перешел пользователь на страницу с формой
+	VisitorUID string `json:"visitor_uid,omitempty"` //UUID посетителя
+}
+
+type SIPMetadata struct {
+	From              string `json:"from"`                           //Кто звонил
+	Phone             string `json:"phone"`                          //На какой номер звонили
+	CalledAt          int    `json:"called_at"`                      //Когда был звонок, передается в Unix Timestamp
+	Duration          int    `json:"duration"`                       //Длительность звонка в секундах
+	Link              string `json:"link,omitempty"`                 //Ссылка на запись звонка
+	ServiceCode       string `json:"service_code"`                   //Код виджета, который создал неразобранное
+	UniqueID          string `json:"uniq,omitempty"`                 //Уникальный идентификатор звонка
+	IsCallEventNeeded bool   `json:"is_call_event_needed,omitempty"` //Показывать ли уведомление о входящем звонке
+}
+
+type ChatClient struct {
+	Name   string `json:"name"`             //Имя клиента
+	Avatar string `json:"avatar,omitempty"` //Ссылка на аватар клиента
+}
+
+type ChatOrigin struct {
+	ChatID     string `json:"chat_id"`               //ID чата в сервисе чатов
+	Ref        string `json:"ref,omitempty"`         //Ссылка, с которой пришел клиент
+	VisitorUID string `json:"visitor_uid,omitempty"` //UUID посетителя
+}
+
+type ChatsMetadata struct {
+	From            string      `json:"from"`                        //Кто написал
+	ReceivedAt      int         `json:"received_at"`                 //Когда было получено сообщение, передается в Unix Timestamp
+	Service         string      `json:"service"`                     //Название сервиса, из которого пришло сообщение
+	Client          *ChatClient `json:"client,omitempty"`            //Данные клиента
+	Origin          *ChatOrigin `json:"origin,omitempty"`            //Данные источника
+	LastMessageText string      `json:"last_message_text,omitempty"` //Текст последнего сообщения
+	SourceName      string      `json:"source_name,omitempty"`       //Название источника
+}
+
+func (FormsMetadata) Category() UnsortedCategory { return UnsortedForms }
+func (SIPMetadata) Category() UnsortedCategory   { return UnsortedSIP }
+func (ChatsMetadata) Category() UnsortedCategory { return UnsortedChats }
+
+// NewUnsorted returns an incoming lead of the metadata category.
+func NewUnsorted(metadata UnsortedMetadata) (Unsorted, error) {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return Unsorted{}, err
+	}
+
+	return Unsorted{Category: metadata.Category(), Metadata: data}, nil
+}
+
+// TypedMetadata decodes metadata into the type matching unsorted.Category.
+func (u Unsorted) TypedMetadata() (UnsortedMetadata, error) {
+	var metadata UnsortedMetadata
+	switch u.Category {
+	case UnsortedForms:
+		metadata = &FormsMetadata{}
+	case UnsortedSIP:
+		metadata = &SIPMetadata{}
+	case UnsortedChats:
+		metadata = &ChatsMetadata{}
+	default:
+		return nil, fmt.Errorf("unexpected unsorted category: %s", u.Category)
+	}
+
+	if len(u.Metadata) == 0 {
+		return nil, errEmptyValue
+	}
+	if err := json.Unmarshal(u.Metadata, metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// UnsortedSummary is a statistics of incoming leads.
+type UnsortedSummary struct {
+	Total           int                      `json:"total"`             //Количество неразобранных
+	Accepted        int                      `json:"accepted"`          //Количество принятых неразобранных
+	Declined        int                      `json:"declined"`          //Количество отклоненных неразобранных
+	AverageSortTime int                      `json:"average_sort_time"` //Среднее время разбора в секундах
+	Categories      map[UnsortedCategory]int `json:"categories"`        //Количество неразобранных по категориям
+}
+
+// UnsortedFilter describes parameters of Unsorted list and summary requests.
+type UnsortedFilter struct {
+	UIDs       []string
+	Categories []UnsortedCategory
+	PipelineID int
+	CreatedAt  *Range // summary only
+	Order      *Order // by created_at or updated_at, list only
+	Page       int
+	Limit      int // 250 at most
+}
+
+func (f UnsortedFilter) filters() url.Values {
+	q := url.Values{}
+	addStrings(q, "filter[uid]", f.UIDs)
+	for _, category := range f.Categories {
+		q.Add("filter[category][]", string(category))
+	}
+	if f.PipelineID != 0 {
+		q.Set("filter[pipeline_id]", strconv.Itoa(f.PipelineID))
+	}
+
+	return q
+}
+
+func (f UnsortedFilter) values() (url.Values, error) {
+	q := f.filters()
+	if err := addOrder(q, f.Order, "created_at", "updated_at"); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+// summaryValues omits order and pagination, the summary endpoint
+// accepts only the filter.
+func (f UnsortedFilter) summaryValues() url.Values {
+	q := f.filters()
+	addRange(q, "filter[created_at]", f.CreatedAt)
+
+	return q
+}
+
+// UnsortedLeads describes methods available for incoming leads.
+type UnsortedLeads interface {
+	List(ctx context.Context, filter UnsortedFilter) ([]Unsorted, error)
+	Iterate(filter UnsortedFilter) *Iterator[Unsorted]
+	GetByUID(ctx context.Context, uid string) (*Unsorted, error)
+	Summary(ctx context.Context, filter UnsortedFilter) (*UnsortedSummary, error)
+	Create(ctx context.Context, category UnsortedCategory, unsorted []Unsorted) ([]Unsorted, error)
+	Accept(ctx context.Context, uid string, userID, statusID int) (*Unsorted, error)
+	Decline(ctx context.Context, uid string, userID int) (*Unsorted, error)
+	Link(ctx context.Context, uid string, userID int, link Link) (*Unsorted, error)
+}
+
+// Verify interface compliance.
+var _ UnsortedLeads = unsorted{}
+
+type unsorted struct {
+	api *api
+}
+
+func newUnsorted(api *api) UnsortedLeads {
+	return unsorted{api: api}
+}
+
+func unsortedUIDEndpoint(uid string) endpoint {
+	return unsortedEndpoint + endpoint("/"+url.PathEscape(uid))
+}
+
+func (a unsorted) List(ctx context.Context, filter UnsortedFilter) ([]Unsorted, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Unsorted](ctx, a.api, unsortedEndpoint, query, "unsorted")
+	return items, err
+}
+
+// Iterate walks all incoming leads matching the filter starting from filter.Page.
+func (a unsorted) Iterate(filter UnsortedFilter) *Iterator[Unsorted] {
+	query, err := filter.values()
+	return iterate[Unsorted](a.api, unsortedEndpoint, "unsorted", filter.Page, query, err)
+}
+
+func (a unsorted) GetByUID(ctx context.Context, uid string) (*Unsorted, error) {
+	item := &Unsorted{}
+	if err := a.api.get(ctx, unsortedUIDEndpoint(uid), nil, item); err != nil {
+		return nil, fmt.Errorf("get unsorted %s: %w", uid, err)
+	}
+
+	return item, nil
+}
+
+// Summary returns statistics of incoming leads. Order, Page and Limit of
+// the filter are ignored.
+func (a unsorted) Summary(ctx context.Context, filter UnsortedFilter) (*UnsortedSummary, error) {
+	summary := &UnsortedSummary{}
+	if err := a.api.get(ctx, unsortedEndpoint+"/summary", filter.summaryValues(), summary); err != nil {
+		return nil, fmt.Errorf("get unsorted summary: %w", err)
+	}
+
+	return summary, nil
+}
+
+// Create adds incoming leads of the category. Use NewUnsorted to fill
+// the metadata and set lead, contacts and companies in Embedded.
+func (a unsorted) Create(ctx context.Context, category UnsortedCategory, unsorted []Unsorted) ([]Unsorted, error) {
+	resp, rErr := a.api.do(ctx, unsortedEndpoint+endpoint("/"+string(category)), http.MethodPost, nil, nil, unsorted)
+	if rErr != nil {
+		return nil, fmt.Errorf("create unsorted: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Unsorted []Unsorted `json:"unsorted"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Unsorted, nil
+}
+
+// Accept turns the incoming lead into a lead with the status. Zero userID
+// and statusID keep the defaults. The result embeds IDs of created entities.
+func (a unsorted) Accept(ctx context.Context, uid string, userID, statusID int) (*Unsorted, error) {
+	data := struct {
+		UserID   int `json:"user_id,omitempty"`
+		StatusID int `json:"status_id,omitempty"`
+	}{userID, statusID}
+
+	return a.action(ctx, http.MethodPost, uid, "accept", data)
+}
+
+func (a unsorted) Decline(ctx context.Context, uid string, userID int) (*Unsorted, error) {
+	data := struct {
+		UserID int `json:"user_id,omitempty"`
+	}{userID}
+
+	return a.action(ctx, http.MethodDelete, uid, "decline", data)
+}
+
+// Link attaches the incoming lead to an existing entity, only
+// link.ToEntityID, link.ToEntityType and link.Metadata are used.
+func (a unsorted) Link(ctx context.Context, uid string, userID int, link Link) (*Unsorted, error) {
+	data := struct {
+		UserID int `json:"user_id,omitempty"`
+		Link   struct {
+			EntityID   int           `json:"entity_id"`
+			EntityType EntityType    `json:"entity_type"`
+			Metadata   *LinkMetadata `json:"metadata,omitempty"`
+		} `json:"link"`
+	}{UserID: userID}
+	data.Link.EntityID = link.ToEntityID
+	data.Link.EntityType = link.ToEntityType
+	data.Link.Metadata = link.Metadata
+
+	return a.action(ctx, http.MethodPost, uid, "link", data)
+}
+
+func (a unsorted) action(ctx context.Context, method, uid, action string, data interface{}) (*Unsorted, error) {
+	resp, rErr := a.api.do(ctx, unsortedUIDEndpoint(uid)+endpoint("/"+action), method, nil, nil, data)
+	if rErr != nil {
+		return nil, fmt.Errorf("%s unsorted: %w", action, rErr)
+	}
+
+	res := &Unsorted{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsorted_CreateForms(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v4/leads/unsorted/forms", r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{
+			"source_uid": "a1fee7c0fc436088e64ba2e8822ba2b3",
+			"source_name": "Сайт",
+			"category": "forms",
+			"metadata": {"form_id": "1", "form_name": "Заявка", "form_page": "https://example.com", "ip": "127.0.0.1", "form_sent_at": 1600000000},
+			"_embedded": {"leads": [{"name": "Заявка с сайта"}], "contacts": [{"name": "Иван"}]}
+		}]`, string(body))
+
+		_, _ = w.Write([]byte(`{"_embedded": {"unsorted": [{
+			"uid": "f03c796fb5455667e648dd0ec9755fc9", "account_id": 1,
+			"_embedded": {"leads": [{"id": 10}], "contacts": [{"id": 11}], "companies": []}
+		}]}}`))
+	}))
+
+	item, err := NewUnsorted(FormsMetadata{
+		FormID:     "1",
+		FormName:   "Заявка",
+		FormPage:   "https://example.com",
+		IP:         "127.0.0.1",
+		FormSentAt: 1600000000,
+	})
+	require.NoError(t, err)
+	item.SourceUID = "a1fee7c0fc436088e64ba2e8822ba2b3"
+	item.SourceName = "Сайт"
+	item.Embedded = &UnsortedEmbedded{
+		Leads:    []Lead{{Name: "Заявка с сайта"}},
+		Contacts: []Contact{{Name: "Иван"}},
+	}
+
+	items, err := unsorted{api: a}.Create(context.Background(), UnsortedForms, []Unsorted{item})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "f03c796fb5455667e648dd0ec9755fc9", items[0].UID)
+	require.Equal(t, 10, items[0].Embedded.Leads[0].Id)
+	require.Equal(t, 11, items[0].Embedded.Contacts[0].Id)
+}
+
+func TestUnsorted_Accept(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v4/leads/unsorted/f03c796f/accept", r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"user_id": 5, "status_id": 142}`, string(body))
+
+		_, _ = w.Write([]byte(`{"uid": "f03c796f", "_embedded": {"leads": [{"id": 10}], "contacts": [{"id": 11}]}}`))
+	}))
+
+	item, err := unsorted{api: a}.Accept(context.Background(), "f03c796f", 5, StatusWon)
+	require.NoError(t, err)
+	require.Equal(t, 10, item.Embedded.Leads[0].Id)
+}
+
+func TestUnsorted_Summary(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/leads/unsorted/summary", r.URL.Path)
+		assert.Equal(t, url.Values{
+			"filter[category][]":       {"forms"},
+			"filter[pipeline_id]":      {"1300"},
+			"filter[created_at][from]": {"1600000000"},
+		}, r.URL.Query())
+		_, _ = w.Write([]byte(`{"total": 5, "accepted": 3, "declined": 1, "average_sort_time": 60, "categories": {"forms": 5}}`))
+	}))
+
+	summary, err := unsorted{api: a}.Summary(context.Background(), UnsortedFilter{
+		Categories: []UnsortedCategory{UnsortedForms},
+		PipelineID: 1300,
+		CreatedAt:  &Range{From: 1600000000},
+		Order:      &Order{Field: "created_at", Direction: OrderDesc},
+		Page:       2,
+		Limit:      50,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &UnsortedSummary{
+		Total:           5,
+		Accepted:        3,
+		Declined:        1,
+		AverageSortTime: 60,
+		Categories:      map[UnsortedCategory]int{UnsortedForms: 5},
+	}, summary)
+}
+
+func TestUnsorted_TypedMetadata(t *testing.T) {
+	item := Unsorted{
+		Category: UnsortedSIP,
+		Metadata: []byte(`{"from": "+79999999999", "phone": "+74950000000", "called_at": 1600000000, "duration": 42, "service_code": "pbx"}`),
+	}
+
+	metadata, err := item.TypedMetadata()
+	require.NoError(t, err)
+	require.Equal(t, &SIPMetadata{From: "+79999999999", Phone: "+74950000000", CalledAt: 1600000000, Duration: 42, ServiceCode: "pbx"}, metadata)
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	usersEndpoint endpoint = "users"
+	rolesEndpoint endpoint = "roles"
+)
+
+// Relations available for Users.
+const (
+	WithRole        = "role"
+	WithGroup       = "group"
+	WithUserRank    = "user_rank"
+	WithPhoneNumber = "phone_number"
+)
+
+// WithUsers adds users to roles.
+const WithUsers = "users"
+
+var userRelations = []string{
+	WithRole,
+	WithGroup,
+	WithUUID,
+	WithAmojoID,
+	WithUserRank,
+	WithPhoneNumber,
+}
+
+// Access levels of user rights.
+const (
+	RightAll    = "A" //Полный доступ
+	RightGroup  = "G" //Доступ к сущностям своей группы
+	RightMine   = "M" //Доступ только к своим сущностям
+	RightDenied = "D" //Доступ запрещен
+)
+
+type EntityRights struct {
+	View   string `json:"view,omitempty"`   //Просмотр
+	Edit   string `json:"edit,omitempty"`   //Редактирование
+	Add    string `json:"add,omitempty"`    //Добавление
+	Delete string `json:"delete,omitempty"` //Удаление
+	Export string `json:"export,omitempty"` //Экспорт
+}
+
+type StatusRights struct {
+	EntityType string       `json:"entity_type"` //Тип сущности, сейчас только leads
+	PipelineID int          `json:"pipeline_id"` //ID воронки
+	StatusID   int          `json:"status_id"`   //ID статуса
+	Rights     EntityRights `json:"rights"`      //Права на сделки в статусе
+}
+
+type UserRights struct {
+	Leads         *EntityRights  `json:"leads,omitempty"`          //Права на сделки
+	Contacts      *EntityRights  `json:"contacts,omitempty"`       //Права на контакты
+	Companies     *EntityRights  `json:"companies,omitempty"`      //Права на компании
+	Tasks         *EntityRights  `json:"tasks,omitempty"`          //Права на задачи
+	MailAccess    *bool          `json:"mail_access,omitempty"`    //Доступ к почте
+	CatalogAccess *bool          `json:"catalog_access,omitempty"` //Доступ к спискам
+	StatusRights  []StatusRights `json:"status_rights,omitempty"`  //Права на сделки по статусам
+	IsAdmin       *bool          `json:"is_admin,omitempty"`       //Является ли пользователь администратором
+	IsFree        *bool          `json:"is_free,omitempty"`        //Является ли пользователь бесплатным
+	IsActive      *bool          `json:"is_active,omitempty"`      //Является ли пользователь активным
+	GroupID       *int           `json:"group_id,omitempty"`       //ID группы, к которой относится пользователь
+	RoleID        *int           `json:"role_id,omitempty"`        //ID роли, которая установлена у пользователя
+}
+
+type UserGroup struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEmbedded struct {
+	Roles  []Role      `json:"roles,omitempty"`  //Роль пользователя. Возвращается при запросе с role
+	Groups []UserGroup `json:"groups,omitempty"` //Группа пользователя. Возвращается при запросе с group
+}
+
+type User struct {
+	ID          int           `json:"id,omitempty"`
+	Name        string        `json:"name,omitempty"`         //Полное имя пользователя
+	Email       string        `json:"email,omitempty"`        //E-mail пользователя
+	Password    string        `json:"password,omitempty"`     //Пароль пользователя, передается только при создании
+	Lang        string        `json:"lang,omitempty"`         //Язык пользователя: ru, en или es
+	Rights      *UserRights   `json:"rights,omitempty"`       //Права пользователя
+	UUID        string        `json:"uuid,omitempty"`         //UUID пользователя. Возвращается при запросе с uuid
+	AmojoID     string        `json:"amojo_id,omitempty"`     //ID пользователя в сервисе чатов. Возвращается при запросе с amojo_id
+	UserRank    string        `json:"user_rank,omitempty"`    //Ранг пользователя. Возвращается при запросе с user_rank
+	PhoneNumber string        `json:"phone_number,omitempty"` //Телефон пользователя. Возвращается при запросе с phone_number
+	Embedded    *UserEmbedded `json:"_embedded,omitempty"`
+}
+
+type RoleEmbedded struct {
+	Users []int `json:"users,omitempty"` //ID пользователей с этой ролью. Возвращается при запросе с users
+}
+
+type Role struct {
+	ID       int           `json:"id,omitempty"`
+	Name     string        `json:"name,omitempty"`   //Название роли
+	Rights   *UserRights   `json:"rights,omitempty"` //Права роли
+	Embedded *RoleEmbedded `json:"_embedded,omitempty"`
+}
+
+// UsersFilter describes parameters of Users list request.
+type UsersFilter struct {
+	Relations []string
+	Page      int
+	Limit     int // 250 at most
+}
+
+func (f UsersFilter) values() (url.Values, error) {
+	q := url.Values{}
+	if err := addRelations(q, "user", f.Relations, userRelations...); err != nil {
+		return nil, err
+	}
+	addPage(q, f.Page, f.Limit)
+
+	return q, nil
+}
+
+// Users describes methods available for account users and their roles.
+type Users interface {
+	List(ctx context.Context, filter UsersFilter) ([]User, error)
+	Iterate(filter UsersFilter) *Iterator[User]
+	GetByID(ctx context.Context, id int, with ...string) (*User, error)
+	Create(ctx context.Context, users []User) ([]User, error)
+	ListRoles(ctx context.Context, with ...string) ([]Role, error)
+	IterateRoles(with ...string) *Iterator[Role]
+	GetRole(ctx context.Context, id int, with ...string) (*Role, error)
+	CreateRoles(ctx context.Context, roles []Role) ([]Role, error)
+	UpdateRole(ctx context.Context, role Role) (*Role, error)
+	DeleteRole(ctx context.Context, id int) error
+}
+
+// Verify interface compliance.
+var _ Users = users{}
+
+type users struct {
+	api *api
+}
+
+func newUsers(api *api) Users {
+	return users{api: api}
+}
+
+func (a users) List(ctx context.Context, filter UsersFilter) ([]User, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[User](ctx, a.api, usersEndpoint, query, "users")
+	return items, err
+}
+
+// Iterate walks all users starting from filter.Page.
+func (a users) Iterate(filter UsersFilter) *Iterator[User] {
+	query, err := filter.values()
+	return iterate[User](a.api, usersEndpoint, "users", filter.Page, query, err)
+}
+
+func (a users) GetByID(ctx context.Context, id int, with ...string) (*User, error) {
+	query := url.Values{}
+	if err := addRelations(query, "user", with, userRelations...); err != nil {
+		return nil, err
+	}
+
+	user := &User{}
+	if err := a.api.get(ctx, usersEndpoint.id(id), query, user); err != nil {
+		return nil, fmt.Errorf("get user %d: %w", id, err)
+	}
+
+	return user, nil
+}
+
+// Create adds users to the account. New users must have Name, Email and Password set.
+func (a users) Create(ctx context.Context, users []User) ([]User, error) {
+	resp, rErr := a.api.do(ctx, usersEndpoint, http.MethodPost, nil, nil, users)
+	if rErr != nil {
+		return nil, fmt.Errorf("create users: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Users []User `json:"users"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Users, nil
+}
+
+func (a users) ListRoles(ctx context.Context, with ...string) ([]Role, error) {
+	query := url.Values{}
+	if err := addRelations(query, "role", with, WithUsers); err != nil {
+		return nil, err
+	}
+
+	items, _, err := listPage[Role](ctx, a.api, rolesEndpoint, query, "roles")
+	return items, err
+}
+
+// IterateRoles walks all roles of the account page by page.
+func (a users) IterateRoles(with ...string) *Iterator[Role] {
+	query := url.Values{}
+	err := addRelations(query, "role", with, WithUsers)
+	return iterate[Role](a.api, rolesEndpoint, "roles", 1, query, err)
+}
+
+func (a users) GetRole(ctx context.Context, id int, with ...string) (*Role, error) {
+	query := url.Values{}
+	if err := addRelations(query, "role", with, WithUsers); err != nil {
+		return nil, err
+	}
+
+	role := &Role{}
+	if err := a.api.get(ctx, rolesEndpoint.id(id), query, role); err != nil {
+		return nil, fmt.Errorf("get role %d: %w", id, err)
+	}
+
+	return role, nil
+}
+
+func (a users) CreateRoles(ctx context.Context, roles []Role) ([]Role, error) {
+	resp, rErr := a.api.do(ctx, rolesEndpoint, http.MethodPost, nil, nil, roles)
+	if rErr != nil {
+		return nil, fmt.Errorf("create roles: %w", rErr)
+	}
+
+	var res struct {
+		Embedded struct {
+			Roles []Role `json:"roles"`
+		} `json:"_embedded"`
+	}
+	if err := a.api.read(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedded.Roles, nil
+}
+
+func (a users) UpdateRole(ctx context.Context, role Role) (*Role, error) {
+	resp, rErr := a.api.do(ctx, rolesEndpoint.id(role.ID), http.MethodPatch, nil, nil, role)
+	if rErr != nil {
+		return nil, fmt.Errorf("update role: %w", rErr)
+	}
+
+	res := &Role{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a users) DeleteRole(ctx context.Context, id int) error {
+	return a.api.delete(ctx, rolesEndpoint.id(id))
+}
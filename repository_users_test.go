@@ -0,0 +1,130 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsers_List(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/users", r.URL.Path)
+		assert.Equal(t, "with=role%2Cgroup%2Cphone_number", r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"_embedded": {"users": [{
+			"id": 504141, "name": "Менеджер", "email": "manager@example.com", "lang": "ru",
+			"phone_number": "+79999999999",
+			"rights": {
+				"leads": {"view": "A", "edit": "G", "add": "A", "delete": "D", "export": "M"},
+				"mail_access": true, "is_admin": false, "is_active": true, "group_id": 0, "role_id": null,
+				"status_rights": [{"entity_type": "leads", "pipeline_id": 1, "status_id": 142, "rights": {"view": "A", "edit": "D", "delete": "D"}}]
+			},
+			"_embedded": {"roles": [], "groups": [{"id": 0, "name": "Отдел продаж"}]}
+		}]}}`))
+	}))
+
+	items, err := users{api: a}.List(context.Background(), UsersFilter{Relations: []string{WithRole, WithGroup, WithPhoneNumber}})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	user := items[0]
+	require.Equal(t, 504141, user.ID)
+	require.Equal(t, "+79999999999", user.PhoneNumber)
+	require.Equal(t, RightGroup, user.Rights.Leads.Edit)
+	require.NotNil(t, user.Rights.IsActive)
+	require.True(t, *user.Rights.IsActive)
+	require.NotNil(t, user.Rights.IsAdmin)
+	require.False(t, *user.Rights.IsAdmin)
+	require.NotNil(t, user.Rights.GroupID)
+	require.Nil(t, user.Rights.RoleID)
+	require.Equal(t, RightDenied, user.Rights.StatusRights[0].Rights.Edit)
+	require.Equal(t, []UserGroup{{ID: 0, Name: "Отдел продаж"}}, user.Embedded.Groups)
+
+	_, err = users{api: a}.List(context.Background(), UsersFilter{Relations: []string{WithLeads}})
+	require.EqualError(t, err, "unexpected user relation: leads")
+}
+
+func TestUsers_GetRole(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/roles/3", r.URL.Path)
+		assert.Equal(t, WithUsers, r.URL.Query().Get("with"))
+		_, _ = w.Write([]byte(`{"id": 3, "name": "Стажёр", "rights": {"contacts": {"view": "M"}}, "_embedded": {"users": [504141]}}`))
+	}))
+
+	role, err := users{api: a}.GetRole(context.Background(), 3, WithUsers)
+	require.NoError(t, err)
+	require.Equal(t, &Role{
+		ID:       3,
+		Name:     "Стажёр",
+		Rights:   &UserRights{Contacts: &EntityRights{View: RightMine}},
+		Embedded: &RoleEmbedded{Users: []int{504141}},
+	}, role)
+}
+
+func TestUsers_IterateRoles(t *testing.T) {
+	var fetched int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetched, 1)
+		assert.Equal(t, "/api/v4/roles", r.URL.Path)
+		assert.Equal(t, []string{WithUsers}, r.URL.Query()["with"])
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"_links": {"next": {"href": ""}}, "_embedded": {"roles": [{"id": 1, "name": "Менеджер"}]}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"_embedded": {"roles": [{"id": 3, "name": "Стажёр"}]}}`))
+		default:
+			t.Errorf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+
+	roles, err := Collect(context.Background(), users{api: a}.IterateRoles(WithUsers))
+	require.NoError(t, err)
+	require.Equal(t, []Role{{ID: 1, Name: "Менеджер"}, {ID: 3, Name: "Стажёр"}}, roles)
+	require.EqualValues(t, 2, atomic.LoadInt32(&fetched))
+
+	_, err = Collect(context.Background(), users{api: a}.IterateRoles(WithLeads))
+	require.EqualError(t, err, "unexpected role relation: leads")
+	require.EqualValues(t, 2, atomic.LoadInt32(&fetched))
+}
+
+func TestUsers_UpdateRole_RevokeRights(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.URL.Path != "/api/v4/roles/3" || string(body) != `{"id":3,"rights":{"mail_access":false,"catalog_access":false}}` {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, body)
+		}
+		_, _ = w.Write([]byte(`{"id": 3, "rights": {"mail_access": false, "catalog_access": false}}`))
+	}))
+
+	denied := false
+	role, err := users{api: a}.UpdateRole(context.Background(), Role{
+		ID:     3,
+		Rights: &UserRights{MailAccess: &denied, CatalogAccess: &denied},
+	})
+	require.NoError(t, err)
+	require.False(t, *role.Rights.MailAccess)
+}
@@ -21,12 +21,13 @@
 package amocrm
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
 
 const (
-	eventsV2endpoint endpoint = "events"
+	eventsV2endpoint endpoint = "/api/v2/events/"
 )
 
 type (
@@ -48,9 +49,11 @@ type (
 	}
 )
 
-// Events describes methods available for Events entity
+// EventsV2 describes methods of the legacy v2 events endpoint used for
+// phone call notifications. See Events for the v4 audit log.
 type EventsV2 interface {
 	Add(events []Event) ([]EventEmbeddedItem, error)
+	AddContext(ctx context.Context, events []Event) ([]EventEmbeddedItem, error)
 }
 
 // Verify interface compliance.
@@ -66,8 +69,11 @@ func newEventsV2(api *api) EventsV2 {
 
 // Create returns an Contacts entity for successfully added Calls
 func (a eventsV2) Add(events []Event) ([]EventEmbeddedItem, error) {
+	return a.AddContext(context.Background(), events)
+}
 
-	resp, rErr := a.api.do(eventsV2endpoint, http.MethodPost, nil, nil, eventAdd{Add: events})
+func (a eventsV2) AddContext(ctx context.Context, events []Event) ([]EventEmbeddedItem, error) {
+	resp, rErr := a.api.do(ctx, eventsV2endpoint, http.MethodPost, nil, nil, eventAdd{Add: events})
 	if rErr != nil {
 		return nil, fmt.Errorf("get calls: %w", rErr)
 	}
@@ -0,0 +1,156 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	webhooksEndpoint endpoint = "webhooks"
+)
+
+type Webhook struct {
+	ID          int            `json:"id,omitempty"`
+	Destination string         `json:"destination"`          //URL, на который будут приходить вебхуки
+	CreatedAt   int            `json:"created_at,omitempty"` //Дата создания вебхука, передается в Unix Timestamp
+	UpdatedAt   int            `json:"updated_at,omitempty"` //Дата изменения вебхука, передается в Unix Timestamp
+	AccountID   int            `json:"account_id,omitempty"` //ID аккаунта, в котором установлен вебхук
+	CreatedBy   int            `json:"created_by,omitempty"` //ID пользователя, создавшего вебхук
+	Sort        int            `json:"sort,omitempty"`       //Сортировка вебхука
+	Disabled    bool           `json:"disabled,omitempty"`   //Отключен ли вебхук
+	Settings    []WebhookEvent `json:"settings"`             //События, на которые подписан вебхук
+}
+
+// Webhooks describes methods available for webhook subscriptions.
+type Webhooks interface {
+	List(ctx context.Context, destination string) ([]Webhook, error)
+	Subscribe(ctx context.Context, destination string, events []WebhookEvent) (*Webhook, error)
+	Unsubscribe(ctx context.Context, destination string) error
+	EnsureSubscribed(ctx context.Context, destination string, events []WebhookEvent) (*Webhook, error)
+}
+
+// Verify interface compliance.
+var _ Webhooks = webhooks{}
+
+type webhooks struct {
+	api *api
+}
+
+func newWebhooks(api *api) Webhooks {
+	return webhooks{api: api}
+}
+
+// List returns webhooks of the destination or all of them if it is empty.
+// amoCRM does not split webhooks into pages.
+func (a webhooks) List(ctx context.Context, destination string) ([]Webhook, error) {
+	query := url.Values{}
+	if destination != "" {
+		query.Set("filter[destination]", destination)
+	}
+
+	items, _, err := listPage[Webhook](ctx, a.api, webhooksEndpoint, query, "webhooks")
+	return items, err
+}
+
+// Subscribe sets events the destination is notified on, replacing
+// the ones of the existing subscription.
+func (a webhooks) Subscribe(ctx context.Context, destination string, events []WebhookEvent) (*Webhook, error) {
+	data := Webhook{Destination: destination, Settings: events}
+
+	resp, rErr := a.api.do(ctx, webhooksEndpoint, http.MethodPost, nil, nil, data)
+	if rErr != nil {
+		return nil, fmt.Errorf("subscribe webhook: %w", rErr)
+	}
+
+	res := &Webhook{}
+	if err := a.api.read(resp, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a webhooks) Unsubscribe(ctx context.Context, destination string) error {
+	data := struct {
+		Destination string `json:"destination"`
+	}{destination}
+
+	resp, rErr := a.api.do(ctx, webhooksEndpoint, http.MethodDelete, nil, nil, data)
+	if rErr != nil {
+		return fmt.Errorf("unsubscribe webhook: %w", rErr)
+	}
+	if resp.StatusCode >= 400 {
+		return a.api.read(resp, nil)
+	}
+	discardBody(resp)
+
+	return nil
+}
+
+// EnsureSubscribed subscribes the destination to events keeping the events
+// it is already subscribed to. It does nothing when the active subscription
+// covers all the events, so it is safe to call on every start.
+func (a webhooks) EnsureSubscribed(ctx context.Context, destination string, events []WebhookEvent) (*Webhook, error) {
+	existing, err := a.List(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings []WebhookEvent
+	for _, webhook := range existing {
+		if webhook.Destination != destination {
+			continue
+		}
+		if !webhook.Disabled && coversEvents(webhook.Settings, events) {
+			return &webhook, nil
+		}
+		settings = webhook.Settings
+	}
+
+	for _, event := range events {
+		if !coversEvents(settings, []WebhookEvent{event}) {
+			settings = append(settings, event)
+		}
+	}
+
+	return a.Subscribe(ctx, destination, settings)
+}
+
+func coversEvents(settings, events []WebhookEvent) bool {
+	for _, event := range events {
+		found := false
+		for _, setting := range settings {
+			if setting == event {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const hookURL = "https://example.com/amocrm/hook"
+
+func TestWebhooks_EnsureSubscribed(t *testing.T) {
+	var subscribed []string
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/webhooks", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, hookURL, r.URL.Query().Get("filter[destination]"))
+			_, _ = w.Write([]byte(`{"_embedded": {"webhooks": [
+				{"id": 1, "destination": "` + hookURL + `", "settings": ["add_lead", "status_lead"]}
+			]}}`))
+		case http.MethodPost:
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			subscribed = append(subscribed, string(body))
+			_, _ = w.Write([]byte(`{"id": 1, "destination": "` + hookURL + `", "settings": ["add_lead", "status_lead", "add_contact"]}`))
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+
+	webhook, err := webhooks{api: a}.EnsureSubscribed(context.Background(), hookURL, []WebhookEvent{WebhookStatusLead})
+	require.NoError(t, err)
+	require.Equal(t, 1, webhook.ID)
+	require.Empty(t, subscribed)
+
+	webhook, err = webhooks{api: a}.EnsureSubscribed(context.Background(), hookURL, []WebhookEvent{WebhookStatusLead, WebhookAddContact})
+	require.NoError(t, err)
+	require.Equal(t, []WebhookEvent{WebhookAddLead, WebhookStatusLead, WebhookAddContact}, webhook.Settings)
+	require.Len(t, subscribed, 1)
+	require.JSONEq(t, `{"destination": "`+hookURL+`", "settings": ["add_lead", "status_lead", "add_contact"]}`, subscribed[0])
+}
+
+func TestWebhooks_Unsubscribe(t *testing.T) {
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"destination": "`+hookURL+`"}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	require.NoError(t, webhooks{api: a}.Unsubscribe(context.Background(), hookURL))
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy describes how requests failed with 429, 500, 502, 503, 504
+// or a transient network error are repeated. POST requests are retried
+// only if every entity of the payload has a request_id.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first
+	// one. Values less than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the exponential backoff between
+	// attempts. Retry-After response header takes precedence over it.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxElapsed is the total time budget for all attempts. Zero means
+	// the budget is limited only by MaxAttempts.
+	MaxElapsed time.Duration
+	// OnRetry is called before waiting for every next attempt.
+	OnRetry func(RetryInfo)
+}
+
+// RetryInfo describes a failed attempt that is about to be retried.
+type RetryInfo struct {
+	Attempt    int
+	Method     string
+	URL        string
+	StatusCode int
+	Err        error
+	Delay      time.Duration
+}
+
+// DefaultRetryPolicy is used by clients unless SetRetryPolicy is called.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+	MaxElapsed:  time.Minute,
+}
+
+// delay returns how long to wait before the attempt following the given one.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	// Jitter spreads retries of concurrent requests over [d/2, d).
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1)) //nolint:gosec
+}
+
+// retryAfter parses Retry-After header value given in seconds or as HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		var netErr net.Error
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.EPIPE) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasRequestID reports whether the JSON body is an entity or a list of
+// entities that all carry a non-empty request_id.
+func hasRequestID(body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+
+	var entities []map[string]interface{}
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		if err := json.Unmarshal(body, &entities); err != nil {
+			return false
+		}
+	} else {
+		var entity map[string]interface{}
+		if err := json.Unmarshal(body, &entity); err != nil {
+			return false
+		}
+		entities = append(entities, entity)
+	}
+
+	if len(entities) == 0 {
+		return false
+	}
+	for _, entity := range entities {
+		if id, ok := entity["request_id"]; !ok || id == nil || id == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
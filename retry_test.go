@@ -0,0 +1,157 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+}
+
+func TestAPI_Do_Retry(t *testing.T) {
+	var hits int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `[{"name":"lead"}]`, string(body))
+
+		if atomic.AddInt32(&hits, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"_embedded":{"leads":[{"id":1}]}}`))
+	}))
+
+	var retries []RetryInfo
+	policy := fastRetryPolicy
+	policy.OnRetry = func(info RetryInfo) { retries = append(retries, info) }
+	a.setRetryPolicy(policy)
+
+	got, err := leads{api: a}.UpdateContext(context.Background(), []Lead{{Name: "lead"}})
+	require.NoError(t, err)
+	require.Equal(t, []Lead{{Id: 1}}, got)
+	require.EqualValues(t, 3, atomic.LoadInt32(&hits))
+	require.Len(t, retries, 2)
+	require.Equal(t, http.StatusBadGateway, retries[0].StatusCode)
+	require.Equal(t, 2, retries[1].Attempt)
+}
+
+func TestAPI_Do_Retry_Exhausted(t *testing.T) {
+	var hits int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	a.setRetryPolicy(fastRetryPolicy)
+
+	_, err := leads{api: a}.UpdateContext(context.Background(), []Lead{{Name: "lead"}})
+	require.True(t, errors.Is(err, ErrServerError), err)
+	require.EqualValues(t, 3, atomic.LoadInt32(&hits))
+}
+
+func TestAPI_Do_Retry_PostWithoutRequestID(t *testing.T) {
+	var hits int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	a.setRetryPolicy(fastRetryPolicy)
+
+	_, err := leads{api: a}.CreateContext(context.Background(), []Lead{{Name: "lead"}})
+	require.True(t, errors.Is(err, ErrRateLimited), err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&hits))
+
+	_, _, err = calls{api: a}.CreateContext(context.Background(), []Call{{RequestID: "1"}, {RequestID: "2"}})
+	require.True(t, errors.Is(err, ErrRateLimited), err)
+	require.EqualValues(t, 4, atomic.LoadInt32(&hits))
+}
+
+func TestAPI_Do_Retry_PostWithRequestID(t *testing.T) {
+	var hits int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"_embedded":{"leads":[{"id":1,"request_id":"row-1"}]}}`))
+	}))
+	a.setRetryPolicy(fastRetryPolicy)
+
+	got, err := leads{api: a}.CreateContext(context.Background(), []Lead{{Name: "lead", RequestID: "row-1"}})
+	require.NoError(t, err)
+	require.Equal(t, []Lead{{Id: 1, RequestID: "row-1"}}, got)
+	require.EqualValues(t, 2, atomic.LoadInt32(&hits))
+
+	atomic.StoreInt32(&hits, 0)
+	_, err = leads{api: a}.CreateContext(context.Background(), []Lead{{Name: "lead"}})
+	require.True(t, errors.Is(err, ErrServerError), err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&hits))
+
+	atomic.StoreInt32(&hits, 0)
+	_, err = contacts{api: a}.CreateContext(context.Background(), []Contact{{Name: "contact", RequestID: "row-1"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&hits))
+}
+
+func TestAPI_Do_Retry_NotRetryable(t *testing.T) {
+	var hits int32
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	a.setRetryPolicy(fastRetryPolicy)
+
+	_, err := leads{api: a}.UpdateContext(context.Background(), []Lead{{Name: "lead"}})
+	require.True(t, errors.Is(err, ErrBadRequest), err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&hits))
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, max := range map[int]time.Duration{1: 100, 2: 200, 3: 400, 4: 800, 5: 1000, 10: 1000} {
+		d := p.delay(attempt, nil)
+		require.True(t, d >= max*time.Millisecond/2 && d <= max*time.Millisecond, "attempt %d: %s", attempt, d)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	require.Equal(t, 3*time.Second, p.delay(1, resp))
+}
+
+func TestHasRequestID(t *testing.T) {
+	require.True(t, hasRequestID([]byte(`[{"request_id":"a"},{"request_id":1}]`)))
+	require.True(t, hasRequestID([]byte(`{"request_id":"a"}`)))
+	require.False(t, hasRequestID([]byte(`[{"request_id":"a"},{}]`)))
+	require.False(t, hasRequestID([]byte(`[]`)))
+	require.False(t, hasRequestID(nil))
+}
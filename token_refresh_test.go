@@ -0,0 +1,224 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// oauthServer emulates amoCRM single-use refresh tokens.
+type oauthServer struct {
+	mu        sync.Mutex
+	refresh   string
+	access    string
+	exchanges int32
+	delay     time.Duration
+}
+
+func newOAuthServer() *oauthServer {
+	return &oauthServer{refresh: "refresh_token", access: "access_token"}
+}
+
+func (s *oauthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/oauth2/access_token" {
+		s.mu.Lock()
+		valid := r.Header.Get("Authorization") == "Bearer "+s.access
+		s.mu.Unlock()
+
+		if !valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+		return
+	}
+
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.FormValue("refresh_token") != s.refresh {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"hint":"Token has been revoked","title":"Некорректный запрос","status":400}`))
+		return
+	}
+
+	n := atomic.AddInt32(&s.exchanges, 1)
+	s.refresh = "refresh_token_" + strconv.Itoa(int(n))
+	s.access = "access_token_" + strconv.Itoa(int(n))
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"token_type":    "Bearer",
+		"expires_in":    86400,
+		"access_token":  s.access,
+		"refresh_token": s.refresh,
+	})
+}
+
+type memoryTokenStorage struct {
+	mu     sync.Mutex
+	token  Token
+	writes int
+}
+
+func (s *memoryTokenStorage) SetToken(token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = token
+	s.writes++
+
+	return nil
+}
+
+func (s *memoryTokenStorage) GetToken() (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token, nil
+}
+
+func TestAPI_RefreshToken_Concurrent(t *testing.T) {
+	srv := newOAuthServer()
+	srv.delay = 20 * time.Millisecond
+
+	a := newTestAPI(t, srv)
+	storage := &memoryTokenStorage{}
+	a.storage = storage
+	a.token = NewToken("expired_token", "refresh_token", "bearer", time.Now().Add(-time.Hour))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := accounts{api: a}.CurrentContext(context.Background(), AccountsConfig{})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&srv.exchanges))
+	require.Equal(t, 1, storage.writes)
+	require.Equal(t, "access_token_1", a.currentToken().AccessToken())
+	require.Equal(t, "refresh_token_1", storage.token.RefreshToken())
+}
+
+func TestAPI_RefreshToken_WaiterContext(t *testing.T) {
+	srv := newOAuthServer()
+	srv.delay = 200 * time.Millisecond
+
+	a := newTestAPI(t, srv)
+	stale := NewToken("expired_token", "refresh_token", "bearer", time.Now().Add(-time.Hour))
+	a.token = stale
+
+	leaderDone := make(chan error)
+	go func() {
+		leaderDone <- a.refreshToken(context.Background(), stale)
+	}()
+
+	// Wait for the leader to start the exchange.
+	require.Eventually(t, func() bool {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return a.refresh != nil
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, a.refreshToken(ctx, stale))
+
+	require.NoError(t, <-leaderDone)
+	require.EqualValues(t, 1, atomic.LoadInt32(&srv.exchanges))
+
+	// A caller holding the replaced token does not exchange it again.
+	require.NoError(t, a.refreshToken(context.Background(), stale))
+	require.EqualValues(t, 1, atomic.LoadInt32(&srv.exchanges))
+	require.True(t, strings.HasPrefix(a.currentToken().AccessToken(), "access_token_"))
+}
+
+func TestAPI_Do_ReplayOnUnauthorized(t *testing.T) {
+	srv := newOAuthServer()
+	srv.access = "rotated_token"
+
+	var bodies []string
+	a := newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == leadsEndpoint.path() {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	storage := &memoryTokenStorage{}
+	a.storage = storage
+
+	_, err := leads{api: a}.UpdateContext(context.Background(), []Lead{{Id: 1, Name: "lead"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&srv.exchanges))
+	require.Equal(t, 1, storage.writes)
+	require.Equal(t, "access_token_1", a.currentToken().AccessToken())
+	require.Len(t, bodies, 2)
+	require.Equal(t, bodies[0], bodies[1])
+}
+
+func TestAPI_Do_ReauthorizationRequired(t *testing.T) {
+	srv := newOAuthServer()
+	srv.access = "rotated_token"
+	srv.refresh = "rotated_refresh_token"
+
+	a := newTestAPI(t, srv)
+
+	_, err := accounts{api: a}.CurrentContext(context.Background(), AccountsConfig{})
+	require.True(t, errors.Is(err, ErrReauthorizationRequired), err)
+	require.EqualValues(t, 0, atomic.LoadInt32(&srv.exchanges))
+}
+
+func TestAmoCRM_TokenByCode_Rejected(t *testing.T) {
+	srv := newOAuthServer()
+	a := newTestAPI(t, srv)
+
+	_, err := (&amoCRM{api: a}).TokenByCodeContext(context.Background(), "expired_code")
+	require.False(t, errors.Is(err, ErrReauthorizationRequired), err)
+	require.True(t, errors.Is(err, ErrBadRequest), err)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr), err)
+	require.Equal(t, http.StatusBadRequest, apiErr.Status)
+	require.EqualValues(t, 0, atomic.LoadInt32(&srv.exchanges))
+}
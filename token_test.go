@@ -28,7 +28,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/sintanial/amocrm"
+	"github.com/ros-tel/amocrm"
 )
 
 var (
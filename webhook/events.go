@@ -0,0 +1,409 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/ros-tel/amocrm"
+)
+
+// Account is the account a webhook is sent from.
+type Account struct {
+	ID        int
+	Subdomain string
+	Link      string
+}
+
+// MessageAuthor is an author of a chat message.
+type MessageAuthor struct {
+	ID   string
+	Type string
+	Name string
+}
+
+// Message is a chat message of add_message event.
+type Message struct {
+	ID         string
+	ChatID     string
+	TalkID     int
+	ContactID  int
+	Text       string
+	Type       string // incoming or outgoing
+	EntityType string
+	EntityID   int
+	Origin     string
+	CreatedAt  int
+	Author     MessageAuthor
+}
+
+// Talk is a chat conversation of add_talk and update_talk events.
+type Talk struct {
+	ID         int
+	ChatID     string
+	ContactID  int
+	EntityType string
+	EntityID   int
+	Rate       int
+	IsInWork   bool
+	IsRead     bool
+	Origin     string
+	CreatedAt  int
+	UpdatedAt  int
+}
+
+// Event is a single entity change received with a webhook. Only the field
+// matching the event entity is set, e.g. Lead for status_lead. Notes
+// events set Note only.
+type Event struct {
+	Name    amocrm.WebhookEvent
+	Account Account
+
+	Lead     *amocrm.Lead
+	Contact  *amocrm.Contact
+	Company  *amocrm.Company
+	Task     *amocrm.Task
+	Note     *amocrm.Note
+	Unsorted *amocrm.Unsorted
+	Message  *Message
+	Talk     *Talk
+
+	// Previous values of status and responsible events.
+	OldStatusID          int
+	OldPipelineID        int
+	OldResponsibleUserID int
+
+	// Raw holds all the fields of the entity as nested maps of strings,
+	// e.g. Raw["custom_fields"].(map[string]interface{})["0"].
+	Raw map[string]interface{}
+}
+
+// singular names of entities used in event names, by form keys.
+var entities = map[string]string{
+	"leads":     "lead",
+	"contacts":  "contact",
+	"companies": "company",
+	"customers": "customer",
+	"task":      "task",
+	"unsorted":  "unsorted",
+	"message":   "message",
+	"talk":      "talk",
+}
+
+// ParseValues decodes form values of a webhook into events.
+// Entities unknown to the package are reported with Raw only.
+func ParseValues(values url.Values) ([]Event, error) {
+	root, err := buildTree(values)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := root.node("account")
+	account := Account{
+		ID:        acc.int("id"),
+		Subdomain: acc.str("subdomain"),
+		Link:      acc.node("_links").str("self"),
+	}
+
+	var events []Event
+	for _, key := range root.keys() {
+		entity, ok := entities[key]
+		if !ok {
+			continue
+		}
+
+		actions := root.node(key)
+		for _, action := range actions.keys() {
+			for _, item := range actions.list(action) {
+				events = append(events, newEvent(account, entity, action, item))
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func newEvent(account Account, entity, action string, item node) Event {
+	// Companies come with contacts events marked by type.
+	if entity == "contact" && item.str("type") == "company" {
+		entity = "company"
+	}
+
+	e := Event{
+		Name:                 amocrm.WebhookEvent(action + "_" + entity),
+		Account:              account,
+		OldStatusID:          item.int("old_status_id"),
+		OldPipelineID:        item.int("old_pipeline_id"),
+		OldResponsibleUserID: item.int("old_responsible_user_id"),
+		Raw:                  item.plain(),
+	}
+
+	if action == "note" {
+		e.Name = amocrm.WebhookEvent("note_" + entity)
+		e.Note = parseNote(item.node("note"))
+		return e
+	}
+
+	switch entity {
+	case "lead":
+		e.Lead = parseLead(item)
+	case "contact":
+		e.Contact = parseContact(item)
+	case "company":
+		e.Company = parseCompany(item)
+	case "task":
+		e.Task = parseTask(item)
+	case "unsorted":
+		e.Unsorted = parseUnsorted(item)
+	case "message":
+		e.Message = parseMessage(item)
+	case "talk":
+		e.Talk = parseTalk(item)
+	}
+
+	return e
+}
+
+func parseLead(n node) *amocrm.Lead {
+	lead := &amocrm.Lead{
+		Id:                 n.int("id"),
+		Name:               n.str("name"),
+		Price:              n.int("price"),
+		StatusId:           n.int("status_id"),
+		PipelineId:         n.int("pipeline_id"),
+		LossReasonId:       n.int("loss_reason_id"),
+		ResponsibleUserId:  n.int("responsible_user_id"),
+		CreatedBy:          n.int("created_user_id"),
+		UpdatedBy:          n.int("modified_user_id"),
+		CreatedAt:          createdAt(n),
+		UpdatedAt:          updatedAt(n),
+		AccountId:          n.int("account_id"),
+		CustomFieldsValues: parseCustomFields(n),
+	}
+	if tags := parseTags(n); tags != nil {
+		lead.Embedded = &amocrm.LeadEmbedded{Tags: tags}
+	}
+
+	return lead
+}
+
+func parseContact(n node) *amocrm.Contact {
+	contact := &amocrm.Contact{
+		Id:                 n.int("id"),
+		Name:               n.str("name"),
+		FirstName:          n.str("first_name"),
+		LastName:           n.str("last_name"),
+		ResponsibleUserId:  n.int("responsible_user_id"),
+		CreatedBy:          n.int("created_user_id"),
+		UpdatedBy:          n.int("modified_user_id"),
+		CreatedAt:          createdAt(n),
+		UpdatedAt:          updatedAt(n),
+		AccountId:          n.int("account_id"),
+		CustomFieldsValues: parseCustomFields(n),
+	}
+
+	tags := parseTags(n)
+	companyID := n.int("linked_company_id")
+	if tags != nil || companyID != 0 {
+		contact.Embedded = &amocrm.ContactsEmbedded{Tags: tags}
+		if companyID != 0 {
+			contact.Embedded.Companies = []amocrm.EmbeddedCompany{{Id: companyID}}
+		}
+	}
+
+	return contact
+}
+
+func parseCompany(n node) *amocrm.Company {
+	company := &amocrm.Company{
+		Id:                 n.int("id"),
+		Name:               n.str("name"),
+		ResponsibleUserId:  n.int("responsible_user_id"),
+		CreatedBy:          n.int("created_user_id"),
+		UpdatedBy:          n.int("modified_user_id"),
+		CreatedAt:          createdAt(n),
+		UpdatedAt:          updatedAt(n),
+		AccountId:          n.int("account_id"),
+		CustomFieldsValues: parseCustomFields(n),
+	}
+	if tags := parseTags(n); tags != nil {
+		company.Embedded = &amocrm.CompanyEmbedded{Tags: tags}
+	}
+
+	return company
+}
+
+// elementTypes maps element_type of webhooks to entity types.
+var elementTypes = map[int]amocrm.EntityType{
+	1:  amocrm.EntityContacts,
+	2:  amocrm.EntityLeads,
+	3:  amocrm.EntityCompanies,
+	12: amocrm.EntityCustomers,
+}
+
+func parseTask(n node) *amocrm.Task {
+	completed := n.bool("status")
+	task := &amocrm.Task{
+		ID:                n.int("id"),
+		EntityID:          n.int("element_id"),
+		EntityType:        elementTypes[n.int("element_type")],
+		TaskTypeID:        n.int("task_type"),
+		Text:              n.str("text"),
+		IsCompleted:       &completed,
+		ResponsibleUserID: n.int("responsible_user_id"),
+		CreatedBy:         n.int("created_user_id"),
+		UpdatedBy:         n.int("modified_user_id"),
+		CreatedAt:         createdAt(n),
+		UpdatedAt:         updatedAt(n),
+		CompleteTill:      n.int("complete_till"),
+		AccountID:         n.int("account_id"),
+	}
+	if text := n.node("result").str("text"); text != "" {
+		task.Result = &amocrm.TaskResult{Text: text}
+	}
+
+	return task
+}
+
+// noteTypes maps numeric note types of webhooks to note types.
+var noteTypes = map[int]amocrm.NoteType{
+	4:   amocrm.NoteCommon,
+	10:  amocrm.NoteCallIn,
+	11:  amocrm.NoteCallOut,
+	25:  amocrm.NoteServiceMessage,
+	102: amocrm.NoteSMSIn,
+	103: amocrm.NoteSMSOut,
+}
+
+func parseNote(n node) *amocrm.Note {
+	note := &amocrm.Note{
+		ID:                n.int("id"),
+		EntityID:          n.int("element_id"),
+		NoteType:          noteTypes[n.int("note_type")],
+		ResponsibleUserID: n.int("responsible_user_id"),
+		CreatedBy:         n.int("created_by"),
+		CreatedAt:         createdAt(n),
+		UpdatedAt:         updatedAt(n),
+		AccountID:         n.int("account_id"),
+	}
+	if text := n.str("text"); text != "" {
+		note.Params, _ = json.Marshal(amocrm.CommonNoteParams{Text: text})
+	}
+
+	return note
+}
+
+func parseUnsorted(n node) *amocrm.Unsorted {
+	return &amocrm.Unsorted{
+		UID:        n.str("uid"),
+		SourceUID:  n.str("source_uid"),
+		SourceName: n.str("source"),
+		Category:   amocrm.UnsortedCategory(n.str("category")),
+		PipelineID: n.int("pipeline_id"),
+		CreatedAt:  n.int("created_at"),
+		AccountID:  n.int("account_id"),
+	}
+}
+
+func parseMessage(n node) *Message {
+	author := n.node("author")
+
+	return &Message{
+		ID:         n.str("id"),
+		ChatID:     n.str("chat_id"),
+		TalkID:     n.int("talk_id"),
+		ContactID:  n.int("contact_id"),
+		Text:       n.str("text"),
+		Type:       n.str("type"),
+		EntityType: n.str("entity_type"),
+		EntityID:   n.int("entity_id"),
+		Origin:     n.str("origin"),
+		CreatedAt:  n.int("created_at"),
+		Author: MessageAuthor{
+			ID:   author.str("id"),
+			Type: author.str("type"),
+			Name: author.str("name"),
+		},
+	}
+}
+
+func parseTalk(n node) *Talk {
+	return &Talk{
+		ID:         n.int("talk_id"),
+		ChatID:     n.str("chat_id"),
+		ContactID:  n.int("contact_id"),
+		EntityType: n.str("entity_type"),
+		EntityID:   n.int("entity_id"),
+		Rate:       n.int("rate"),
+		IsInWork:   n.bool("is_in_work"),
+		IsRead:     n.bool("is_read"),
+		Origin:     n.str("origin"),
+		CreatedAt:  n.int("created_at"),
+		UpdatedAt:  n.int("updated_at"),
+	}
+}
+
+func parseCustomFields(n node) amocrm.CustomFieldsValues {
+	var fields amocrm.CustomFieldsValues
+	for _, field := range n.list("custom_fields") {
+		value := amocrm.CustomFieldValue{
+			FieldID:   field.int("id"),
+			FieldCode: field.str("code"),
+			FieldName: field.str("name"),
+		}
+		for _, v := range field.list("values") {
+			item := amocrm.TextValue(v.str("value"))
+			item.EnumID = v.int("enum")
+			value.Values = append(value.Values, item)
+		}
+		fields = append(fields, value)
+	}
+
+	return fields
+}
+
+func parseTags(n node) []amocrm.Tag {
+	var tags []amocrm.Tag
+	for _, tag := range n.list("tags") {
+		tags = append(tags, amocrm.Tag{ID: tag.int("id"), Name: tag.str("name")})
+	}
+
+	return tags
+}
+
+// createdAt prefers created_at and falls back to date_create of older hooks.
+func createdAt(n node) int {
+	if t := n.int("created_at"); t != 0 {
+		return t
+	}
+
+	return n.int("date_create")
+}
+
+// updatedAt prefers updated_at and falls back to last_modified of older hooks.
+func updatedAt(n node) int {
+	if t := n.int("updated_at"); t != 0 {
+		return t
+	}
+
+	return n.int("last_modified")
+}
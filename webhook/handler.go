@@ -0,0 +1,137 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package webhook receives amoCRM webhooks.
+//
+//	h := webhook.NewHandler()
+//	h.Handle(amocrm.WebhookStatusLead, func(e webhook.Event) {
+//		log.Printf("lead %d moved from %d to %d", e.Lead.Id, e.OldStatusID, e.Lead.StatusId)
+//	})
+//	http.Handle("/amocrm/hook", h)
+//
+// amoCRM disables a webhook that keeps failing or answering slowly, so
+// the handler responds as soon as the body is parsed and runs callbacks
+// in the background.
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ros-tel/amocrm"
+)
+
+// maxBodySize limits the size of webhook requests.
+const maxBodySize = 10 << 20
+
+// Handler is an http.Handler dispatching webhook events to callbacks.
+type Handler struct {
+	mu       sync.RWMutex
+	handlers map[amocrm.WebhookEvent][]func(Event)
+	all      []func(Event)
+	onError  func(error)
+}
+
+// NewHandler returns a Handler without callbacks.
+func NewHandler() *Handler {
+	return &Handler{handlers: make(map[amocrm.WebhookEvent][]func(Event))}
+}
+
+// Handle registers fn for events with the name.
+func (h *Handler) Handle(name amocrm.WebhookEvent, fn func(Event)) {
+	h.mu.Lock()
+	h.handlers[name] = append(h.handlers[name], fn)
+	h.mu.Unlock()
+}
+
+// HandleAll registers fn for every event.
+func (h *Handler) HandleAll(fn func(Event)) {
+	h.mu.Lock()
+	h.all = append(h.all, fn)
+	h.mu.Unlock()
+}
+
+// OnError sets fn to report malformed requests and panics of callbacks.
+func (h *Handler) OnError(fn func(error)) {
+	h.mu.Lock()
+	h.onError = fn
+	h.mu.Unlock()
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	if err := r.ParseForm(); err != nil {
+		h.error(fmt.Errorf("parse webhook: %w", err))
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	events, err := ParseValues(r.PostForm)
+	if err != nil {
+		h.error(fmt.Errorf("parse webhook: %w", err))
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	go h.dispatch(events)
+}
+
+func (h *Handler) dispatch(events []Event) {
+	for _, e := range events {
+		h.mu.RLock()
+		handlers := make([]func(Event), 0, len(h.handlers[e.Name])+len(h.all))
+		handlers = append(handlers, h.handlers[e.Name]...)
+		handlers = append(handlers, h.all...)
+		h.mu.RUnlock()
+
+		for _, fn := range handlers {
+			h.call(fn, e)
+		}
+	}
+}
+
+func (h *Handler) call(fn func(Event), e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.error(fmt.Errorf("webhook %s callback panic: %v", e.Name, r))
+		}
+	}()
+
+	fn(e)
+}
+
+func (h *Handler) error(err error) {
+	h.mu.RLock()
+	onError := h.onError
+	h.mu.RUnlock()
+
+	if onError != nil {
+		onError(err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ros-tel/amocrm"
+)
+
+func TestParseValues_LeadStatus(t *testing.T) {
+	events, err := ParseValues(url.Values{
+		"account[subdomain]":                                   {"example"},
+		"account[id]":                                          {"29085955"},
+		"leads[status][0][id]":                                 {"1"},
+		"leads[status][0][name]":                               {"Сделка"},
+		"leads[status][0][status_id]":                          {"142"},
+		"leads[status][0][old_status_id]":                      {"10"},
+		"leads[status][0][pipeline_id]":                        {"3"},
+		"leads[status][0][old_pipeline_id]":                    {"3"},
+		"leads[status][0][price]":                              {"990"},
+		"leads[status][0][last_modified]":                      {"1600000000"},
+		"leads[status][0][custom_fields][0][id]":               {"11"},
+		"leads[status][0][custom_fields][0][name]":             {"Источник"},
+		"leads[status][0][custom_fields][0][values][0][value]": {"Сайт"},
+		"leads[status][0][custom_fields][0][values][0][enum]":  {"21"},
+		"leads[status][0][tags][0][id]":                        {"5"},
+		"leads[status][0][tags][0][name]":                      {"vip"},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	e := events[0]
+	require.Equal(t, amocrm.WebhookStatusLead, e.Name)
+	require.Equal(t, Account{ID: 29085955, Subdomain: "example"}, e.Account)
+	require.Equal(t, 10, e.OldStatusID)
+	require.Equal(t, 1, e.Lead.Id)
+	require.Equal(t, amocrm.StatusWon, e.Lead.StatusId)
+	require.Equal(t, 990, e.Lead.Price)
+	require.Equal(t, 1600000000, e.Lead.UpdatedAt)
+	require.Equal(t, []amocrm.Tag{{ID: 5, Name: "vip"}}, e.Lead.Embedded.Tags)
+	require.Equal(t, "Сайт", e.Lead.CustomFieldsValues.ByID(11).AsString())
+	require.Equal(t, 21, e.Lead.CustomFieldsValues.ByID(11).Values[0].EnumID)
+	require.Equal(t, "1", e.Raw["id"])
+}
+
+func TestParseValues_ContactsAndNotes(t *testing.T) {
+	events, err := ParseValues(url.Values{
+		"contacts[add][0][id]":                {"7"},
+		"contacts[add][0][name]":              {"Иван"},
+		"contacts[add][0][type]":              {"contact"},
+		"contacts[add][0][linked_company_id]": {"8"},
+		"contacts[add][1][id]":                {"8"},
+		"contacts[add][1][name]":              {"ООО Ромашка"},
+		"contacts[add][1][type]":              {"company"},
+		"leads[note][0][note][id]":            {"9"},
+		"leads[note][0][note][element_id]":    {"1"},
+		"leads[note][0][note][note_type]":     {"4"},
+		"leads[note][0][note][text]":          {"Перезвонить"},
+		"task[add][0][id]":                    {"3"},
+		"task[add][0][element_id]":            {"1"},
+		"task[add][0][element_type]":          {"2"},
+		"task[add][0][text]":                  {"Позвонить"},
+		"unsorted[delete][0][uid]":            {"f03c796f"},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+
+	require.Equal(t, amocrm.WebhookAddContact, events[0].Name)
+	require.Equal(t, []amocrm.EmbeddedCompany{{Id: 8}}, events[0].Contact.Embedded.Companies)
+	require.Equal(t, amocrm.WebhookAddCompany, events[1].Name)
+	require.Equal(t, "ООО Ромашка", events[1].Company.Name)
+
+	require.Equal(t, amocrm.WebhookNoteLead, events[2].Name)
+	params, err := events[2].Note.TypedParams()
+	require.NoError(t, err)
+	require.Equal(t, &amocrm.CommonNoteParams{Text: "Перезвонить"}, params)
+
+	require.Equal(t, amocrm.WebhookAddTask, events[3].Name)
+	require.Equal(t, amocrm.EntityLeads, events[3].Task.EntityType)
+
+	require.Equal(t, amocrm.WebhookDeleteUnsorted, events[4].Name)
+	require.Equal(t, "f03c796f", events[4].Unsorted.UID)
+}
+
+func TestParseValues_MalformedKey(t *testing.T) {
+	_, err := ParseValues(url.Values{"leads[add][0": {"1"}})
+	require.EqualError(t, err, "malformed key: leads[add][0")
+}
+
+func TestHandler(t *testing.T) {
+	received := make(chan Event, 2)
+	errs := make(chan error, 1)
+
+	h := NewHandler()
+	h.Handle(amocrm.WebhookAddLead, func(e Event) { received <- e })
+	h.HandleAll(func(e Event) { panic("boom") })
+	h.OnError(func(err error) { errs <- err })
+
+	body := url.Values{"leads[add][0][id]": {"1"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	select {
+	case e := <-received:
+		require.Equal(t, 1, e.Lead.Id)
+	case <-time.After(time.Second):
+		t.Fatal("callback is not called")
+	}
+	select {
+	case err := <-errs:
+		require.EqualError(t, err, "webhook add_lead callback panic: boom")
+	case <-time.After(time.Second):
+		t.Fatal("panic is not reported")
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hook", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
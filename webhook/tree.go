@@ -0,0 +1,170 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// node is a level of nested form keys, e.g. leads[add][0][id] is
+// stored as node{"leads": node{"add": node{"0": node{"id": "1"}}}}.
+// Values are strings or nodes.
+type node map[string]interface{}
+
+// buildTree nests flat form values by their bracketed keys.
+func buildTree(values url.Values) (node, error) {
+	root := node{}
+	for key, vs := range values {
+		if len(vs) == 0 {
+			continue
+		}
+
+		segments, err := splitKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		cur := root
+		for i, segment := range segments {
+			if segment == "" {
+				segment = strconv.Itoa(len(cur))
+			}
+
+			if i == len(segments)-1 {
+				if _, ok := cur[segment]; ok {
+					return nil, fmt.Errorf("conflicting key: %s", key)
+				}
+				cur[segment] = vs[0]
+				break
+			}
+
+			switch child := cur[segment].(type) {
+			case nil:
+				next := node{}
+				cur[segment] = next
+				cur = next
+			case node:
+				cur = child
+			default:
+				return nil, fmt.Errorf("conflicting key: %s", key)
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// splitKey splits a[b][c] into a, b and c.
+func splitKey(key string) ([]string, error) {
+	i := strings.IndexByte(key, '[')
+	if i < 0 {
+		return []string{key}, nil
+	}
+
+	segments := []string{key[:i]}
+	for rest := key[i:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return nil, fmt.Errorf("malformed key: %s", key)
+		}
+		segments = append(segments, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	return segments, nil
+}
+
+func (n node) node(key string) node {
+	child, _ := n[key].(node)
+	return child
+}
+
+func (n node) str(key string) string {
+	s, _ := n[key].(string)
+	return s
+}
+
+func (n node) int(key string) int {
+	i, _ := strconv.Atoi(n.str(key))
+	return i
+}
+
+func (n node) bool(key string) bool {
+	switch n.str(key) {
+	case "1", "true", "Y":
+		return true
+	default:
+		return false
+	}
+}
+
+// list returns child nodes of n[key] ordered by their numeric keys.
+func (n node) list(key string) []node {
+	child := n.node(key)
+
+	indexes := make([]int, 0, len(child))
+	for k := range child {
+		if i, err := strconv.Atoi(k); err == nil {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+
+	items := make([]node, 0, len(indexes))
+	for _, i := range indexes {
+		if item := child.node(strconv.Itoa(i)); item != nil {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// keys returns keys of child nodes of n in the sorted order.
+func (n node) keys() []string {
+	keys := make([]string, 0, len(n))
+	for k, v := range n {
+		if _, ok := v.(node); ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// plain returns n with nested nodes converted to plain maps.
+func (n node) plain() map[string]interface{} {
+	m := make(map[string]interface{}, len(n))
+	for k, v := range n {
+		if child, ok := v.(node); ok {
+			m[k] = child.plain()
+		} else {
+			m[k] = v
+		}
+	}
+
+	return m
+}
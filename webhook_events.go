@@ -0,0 +1,82 @@
+// Copyright (c) 2021 Alexey Khan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amocrm
+
+// WebhookEvent is a name of an event amoCRM sends webhooks on.
+// Package webhook reports received events with the same names.
+type WebhookEvent string
+
+// Leads events.
+const (
+	WebhookAddLead         WebhookEvent = "add_lead"
+	WebhookUpdateLead      WebhookEvent = "update_lead"
+	WebhookDeleteLead      WebhookEvent = "delete_lead"
+	WebhookRestoreLead     WebhookEvent = "restore_lead"
+	WebhookStatusLead      WebhookEvent = "status_lead"
+	WebhookResponsibleLead WebhookEvent = "responsible_lead"
+	WebhookNoteLead        WebhookEvent = "note_lead"
+)
+
+// Contacts events.
+const (
+	WebhookAddContact         WebhookEvent = "add_contact"
+	WebhookUpdateContact      WebhookEvent = "update_contact"
+	WebhookDeleteContact      WebhookEvent = "delete_contact"
+	WebhookRestoreContact     WebhookEvent = "restore_contact"
+	WebhookResponsibleContact WebhookEvent = "responsible_contact"
+	WebhookNoteContact        WebhookEvent = "note_contact"
+)
+
+// Companies events.
+const (
+	WebhookAddCompany         WebhookEvent = "add_company"
+	WebhookUpdateCompany      WebhookEvent = "update_company"
+	WebhookDeleteCompany      WebhookEvent = "delete_company"
+	WebhookRestoreCompany     WebhookEvent = "restore_company"
+	WebhookResponsibleCompany WebhookEvent = "responsible_company"
+	WebhookNoteCompany        WebhookEvent = "note_company"
+)
+
+// Customers events.
+const (
+	WebhookAddCustomer         WebhookEvent = "add_customer"
+	WebhookUpdateCustomer      WebhookEvent = "update_customer"
+	WebhookDeleteCustomer      WebhookEvent = "delete_customer"
+	WebhookResponsibleCustomer WebhookEvent = "responsible_customer"
+	WebhookNoteCustomer        WebhookEvent = "note_customer"
+)
+
+// Tasks events.
+const (
+	WebhookAddTask         WebhookEvent = "add_task"
+	WebhookUpdateTask      WebhookEvent = "update_task"
+	WebhookDeleteTask      WebhookEvent = "delete_task"
+	WebhookResponsibleTask WebhookEvent = "responsible_task"
+)
+
+// Unsorted, chats and talks events.
+const (
+	WebhookAddUnsorted    WebhookEvent = "add_unsorted"
+	WebhookDeleteUnsorted WebhookEvent = "delete_unsorted"
+	WebhookAddMessage     WebhookEvent = "add_message"
+	WebhookAddTalk        WebhookEvent = "add_talk"
+	WebhookUpdateTalk     WebhookEvent = "update_talk"
+)